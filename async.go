@@ -0,0 +1,157 @@
+package logging
+
+import (
+	"context"
+	"sync"
+)
+
+// OverflowPolicy определяет поведение при переполнении очереди
+type OverflowPolicy int
+
+const (
+	// DropNewest отбрасывает новое событие, если очередь заполнена
+	DropNewest OverflowPolicy = iota
+	// DropOldest вытесняет самое старое событие из очереди
+	DropOldest
+	// Block блокирует вызывающего до освобождения места в очереди
+	Block
+)
+
+const (
+	defaultQueueSize = 1000
+	defaultWorkers   = 1
+)
+
+// AsyncConfig настройки асинхронной доставки
+type AsyncConfig struct {
+	QueueSize int            // размер очереди (по умолчанию 1000)
+	Workers   int            // количество воркеров (по умолчанию 1, сохраняет порядок)
+	Overflow  OverflowPolicy // политика переполнения (по умолчанию DropNewest)
+}
+
+// asyncQueue ограниченная очередь событий с фоновыми воркерами
+type asyncQueue struct {
+	events  chan LogRequest
+	policy  OverflowPolicy
+	stats   *clientStats
+	deliver func(LogRequest)
+	onDrop  func(LogRequest) // вызывается для отброшенного события
+
+	mu        sync.RWMutex
+	closed    bool
+	done      chan struct{}
+	closeOnce sync.Once
+	wg        sync.WaitGroup
+}
+
+// newAsyncQueue создает очередь и запускает воркеров
+func newAsyncQueue(cfg AsyncConfig, stats *clientStats, deliver func(LogRequest)) *asyncQueue {
+	if cfg.QueueSize <= 0 {
+		cfg.QueueSize = defaultQueueSize
+	}
+	if cfg.Workers <= 0 {
+		cfg.Workers = defaultWorkers
+	}
+
+	q := &asyncQueue{
+		events:  make(chan LogRequest, cfg.QueueSize),
+		policy:  cfg.Overflow,
+		stats:   stats,
+		deliver: deliver,
+		done:    make(chan struct{}),
+	}
+	q.wg.Add(cfg.Workers)
+	for i := 0; i < cfg.Workers; i++ {
+		go q.worker()
+	}
+	return q
+}
+
+// worker отправляет события, пока очередь не закрыта и не опустошена
+func (q *asyncQueue) worker() {
+	defer q.wg.Done()
+	for req := range q.events {
+		q.deliver(req)
+	}
+}
+
+// enqueue помещает событие в очередь согласно политике переполнения
+func (q *asyncQueue) enqueue(req LogRequest) error {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+
+	if q.closed {
+		return ErrClientClosed
+	}
+
+	switch q.policy {
+	case Block:
+		select {
+		case q.events <- req:
+			return nil
+		case <-q.done:
+			return ErrClientClosed
+		}
+	case DropOldest:
+		for {
+			select {
+			case q.events <- req:
+				return nil
+			default:
+			}
+			select {
+			case old := <-q.events:
+				q.stats.dropped.Add(1)
+				q.stats.pending.done(old.Sequence)
+				q.drop(old)
+			default:
+			}
+		}
+	default:
+		select {
+		case q.events <- req:
+			return nil
+		default:
+			q.stats.dropped.Add(1)
+			q.drop(req)
+			return ErrQueueFull
+		}
+	}
+}
+
+// drop сообщает об отброшенном событии
+func (q *asyncQueue) drop(req LogRequest) {
+	if q.onDrop != nil {
+		q.onDrop(req)
+	}
+}
+
+// len возвращает текущее количество событий в очереди
+func (q *asyncQueue) len() int {
+	return len(q.events)
+}
+
+// close прекращает прием событий и ждет, пока воркеры разберут очередь
+func (q *asyncQueue) close(ctx context.Context) error {
+	q.closeOnce.Do(func() {
+		// Сначала будим заблокированных отправителей, иначе они держат RLock
+		close(q.done)
+		q.mu.Lock()
+		q.closed = true
+		close(q.events)
+		q.mu.Unlock()
+	})
+
+	finished := make(chan struct{})
+	go func() {
+		q.wg.Wait()
+		close(finished)
+	}()
+
+	select {
+	case <-finished:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
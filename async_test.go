@@ -0,0 +1,223 @@
+package logging
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// blockingServer принимает логи, но отвечает только после закрытия release
+func blockingServer(t *testing.T, release chan struct{}) (*httptest.Server, *[]LogRequest, *sync.Mutex) {
+	t.Helper()
+	var mu sync.Mutex
+	var received []LogRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		var payload LogRequest
+		json.NewDecoder(r.Body).Decode(&payload)
+		mu.Lock()
+		received = append(received, payload)
+		mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+	}))
+	return server, &received, &mu
+}
+
+func TestAsyncClient_DoesNotBlockCaller(t *testing.T) {
+	release := make(chan struct{})
+	server, received, mu := blockingServer(t, release)
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-service", WithAsync(AsyncConfig{QueueSize: 10}))
+
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		if err := client.Info("test_event", "message", nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected non-blocking calls, took %v", elapsed)
+	}
+
+	close(release)
+	if err := client.Close(context.Background()); err != nil {
+		t.Fatalf("unexpected close error: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(*received) != 5 {
+		t.Errorf("expected 5 delivered events, got %d", len(*received))
+	}
+	if stats := client.Stats(); stats.Sent != 5 {
+		t.Errorf("expected Sent 5, got %d", stats.Sent)
+	}
+}
+
+func TestAsyncClient_DropNewest(t *testing.T) {
+	release := make(chan struct{})
+	q := newAsyncQueue(AsyncConfig{QueueSize: 2, Overflow: DropNewest}, &clientStats{}, func(LogRequest) {
+		<-release
+	})
+
+	// Первое событие забирает воркер, два следующих заполняют очередь
+	q.enqueue(LogRequest{Message: "1"})
+	waitFor(t, func() bool { return q.len() == 0 })
+	q.enqueue(LogRequest{Message: "2"})
+	q.enqueue(LogRequest{Message: "3"})
+
+	if err := q.enqueue(LogRequest{Message: "4"}); !errors.Is(err, ErrQueueFull) {
+		t.Errorf("expected ErrQueueFull, got %v", err)
+	}
+	if q.stats.dropped.Load() != 1 {
+		t.Errorf("expected 1 dropped event, got %d", q.stats.dropped.Load())
+	}
+
+	close(release)
+	q.close(context.Background())
+}
+
+func TestAsyncClient_DropOldest(t *testing.T) {
+	release := make(chan struct{})
+	var mu sync.Mutex
+	var delivered []string
+
+	q := newAsyncQueue(AsyncConfig{QueueSize: 2, Overflow: DropOldest}, &clientStats{}, func(req LogRequest) {
+		<-release
+		mu.Lock()
+		delivered = append(delivered, req.Message)
+		mu.Unlock()
+	})
+
+	q.enqueue(LogRequest{Message: "1"})
+	waitFor(t, func() bool { return q.len() == 0 })
+	q.enqueue(LogRequest{Message: "2"})
+	q.enqueue(LogRequest{Message: "3"})
+
+	if err := q.enqueue(LogRequest{Message: "4"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	close(release)
+	q.close(context.Background())
+
+	mu.Lock()
+	defer mu.Unlock()
+	expected := []string{"1", "3", "4"}
+	if len(delivered) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, delivered)
+	}
+	for i := range expected {
+		if delivered[i] != expected[i] {
+			t.Errorf("expected %v, got %v", expected, delivered)
+			break
+		}
+	}
+	if q.stats.dropped.Load() != 1 {
+		t.Errorf("expected 1 dropped event, got %d", q.stats.dropped.Load())
+	}
+}
+
+func TestAsyncClient_Block(t *testing.T) {
+	release := make(chan struct{})
+	q := newAsyncQueue(AsyncConfig{QueueSize: 1, Overflow: Block}, &clientStats{}, func(LogRequest) {
+		<-release
+	})
+
+	q.enqueue(LogRequest{Message: "1"})
+	waitFor(t, func() bool { return q.len() == 0 })
+	q.enqueue(LogRequest{Message: "2"})
+
+	result := make(chan error, 1)
+	go func() {
+		result <- q.enqueue(LogRequest{Message: "3"})
+	}()
+
+	select {
+	case <-result:
+		t.Fatal("expected enqueue to block while queue is full")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(release)
+	select {
+	case err := <-result:
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("enqueue was not unblocked")
+	}
+	q.close(context.Background())
+}
+
+func TestAsyncClient_CloseRejectsNewEvents(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-service", WithAsync(AsyncConfig{}))
+	if err := client.Close(context.Background()); err != nil {
+		t.Fatalf("unexpected close error: %v", err)
+	}
+	if err := client.Info("test_event", "after close", nil); err == nil {
+		t.Error("expected error after close")
+	}
+}
+
+func TestAsyncClient_CloseDeadline(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	q := newAsyncQueue(AsyncConfig{}, &clientStats{}, func(LogRequest) {
+		<-release
+	})
+	q.enqueue(LogRequest{Message: "stuck"})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := q.close(ctx); err != context.DeadlineExceeded {
+		t.Errorf("expected deadline exceeded, got %v", err)
+	}
+}
+
+// waitFor ждет выполнения условия не дольше секунды
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition was not met in time")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestAsyncClient_CopiesCallerMetadata(t *testing.T) {
+	server, received := captureServer(t)
+	client := NewClient(server.URL, "test-service", WithAsync(AsyncConfig{QueueSize: 100}))
+
+	// Вызывающий переиспользует карту; go test -race ловит гонку с воркером
+	metadata := map[string]interface{}{}
+	for i := 0; i < 50; i++ {
+		metadata["i"] = i
+		client.Info("test_event", "message", metadata)
+	}
+	client.Close(context.Background())
+
+	events := received()
+	if len(events) != 50 {
+		t.Fatalf("expected 50 events, got %d", len(events))
+	}
+	for i, event := range events {
+		if event.Metadata["i"] != float64(i) {
+			t.Fatalf("expected event %d to keep its metadata, got %v", i, event.Metadata["i"])
+		}
+	}
+}
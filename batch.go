@@ -0,0 +1,280 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// BatchFormat формат тела пакетного запроса
+type BatchFormat int
+
+const (
+	// BatchJSONArray отправляет пакет как JSON массив
+	BatchJSONArray BatchFormat = iota
+	// BatchNDJSON отправляет пакет как NDJSON (одно событие на строку)
+	BatchNDJSON
+)
+
+const (
+	defaultBatchMaxEvents     = 100
+	defaultBatchMaxBytes      = 1 << 20
+	defaultBatchFlushInterval = time.Second
+	defaultBatchEndpoint      = "/logs/batch"
+
+	// maxPendingBatches ограничивает число готовых пакетов, ожидающих отправки
+	maxPendingBatches = 16
+)
+
+// BatchConfig настройки пакетной отправки
+type BatchConfig struct {
+	MaxEvents     int           // событий в пакете (по умолчанию 100)
+	MaxBytes      int           // размер тела пакета в байтах (по умолчанию 1 MiB)
+	FlushInterval time.Duration // максимальное время накопления (по умолчанию 1s)
+	Endpoint      string        // путь bulk endpoint (по умолчанию /logs/batch)
+	Format        BatchFormat   // формат тела (по умолчанию JSON массив)
+}
+
+// batchItem событие вместе с его JSON представлением
+type batchItem struct {
+	req  LogRequest
+	data []byte
+}
+
+// batcher накапливает события и передает пакеты фоновому отправителю
+type batcher struct {
+	cfg  BatchConfig
+	send func(ctx context.Context, items []batchItem)
+
+	mu       sync.Mutex
+	cond     *sync.Cond
+	buf      []batchItem
+	bufBytes int
+	ready    [][]batchItem
+	closed   bool
+	flushing int // активные Flush: новые события отправляются без ожидания
+
+	// unsupported выставляется, если logging-service не знает bulk endpoint
+	unsupported atomic.Bool
+
+	wake      chan struct{}
+	done      chan struct{}
+	finished  chan struct{}
+	closeOnce sync.Once
+}
+
+// newBatcher создает batcher и запускает фоновую отправку
+func newBatcher(cfg BatchConfig, send func(ctx context.Context, items []batchItem)) *batcher {
+	if cfg.MaxEvents <= 0 {
+		cfg.MaxEvents = defaultBatchMaxEvents
+	}
+	if cfg.MaxBytes <= 0 {
+		cfg.MaxBytes = defaultBatchMaxBytes
+	}
+	if cfg.FlushInterval <= 0 {
+		cfg.FlushInterval = defaultBatchFlushInterval
+	}
+	if cfg.Endpoint == "" {
+		cfg.Endpoint = defaultBatchEndpoint
+	}
+
+	b := &batcher{
+		cfg:      cfg,
+		send:     send,
+		wake:     make(chan struct{}, 1),
+		done:     make(chan struct{}),
+		finished: make(chan struct{}),
+	}
+	b.cond = sync.NewCond(&b.mu)
+	go b.run()
+	return b
+}
+
+// add добавляет событие в текущий пакет
+func (b *batcher) add(req LogRequest) error {
+	data, err := json.Marshal(req)
+	if err != nil {
+		return fmt.Errorf("failed to marshal log payload: %w", err)
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for len(b.ready) >= maxPendingBatches && !b.closed {
+		b.cond.Wait()
+	}
+	if b.closed {
+		return ErrClientClosed
+	}
+
+	// Событие не помещается по размеру - закрываем текущий пакет
+	if len(b.buf) > 0 && b.bufBytes+len(data)+1 > b.cfg.MaxBytes {
+		b.seal()
+	}
+	b.buf = append(b.buf, batchItem{req: req, data: data})
+	b.bufBytes += len(data) + 1
+	if len(b.buf) >= b.cfg.MaxEvents || b.bufBytes >= b.cfg.MaxBytes || b.flushing > 0 {
+		b.seal()
+	}
+	return nil
+}
+
+// seal переносит текущий пакет в очередь готовых. Вызывается под mu.
+func (b *batcher) seal() {
+	if len(b.buf) == 0 {
+		return
+	}
+	b.ready = append(b.ready, b.buf)
+	b.buf = nil
+	b.bufBytes = 0
+	select {
+	case b.wake <- struct{}{}:
+	default:
+	}
+}
+
+// startFlush отправляет текущий пакет без ожидания и, пока идет Flush,
+// запечатывает пакет после каждого нового события
+func (b *batcher) startFlush() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.flushing++
+	b.seal()
+}
+
+// endFlush возвращает обычное накопление пакетов
+func (b *batcher) endFlush() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.flushing--
+}
+
+// take забирает готовые пакеты и, при необходимости, текущий буфер
+func (b *batcher) take(includeCurrent bool) [][]batchItem {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if includeCurrent {
+		b.seal()
+	}
+	batches := b.ready
+	b.ready = nil
+	b.cond.Broadcast()
+	return batches
+}
+
+// run отправляет пакеты по заполнению, по таймеру и при закрытии
+func (b *batcher) run() {
+	defer close(b.finished)
+	ticker := time.NewTicker(b.cfg.FlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-b.wake:
+			b.flush(b.take(false))
+		case <-ticker.C:
+			b.flush(b.take(true))
+		case <-b.done:
+			b.flush(b.take(true))
+			return
+		}
+	}
+}
+
+// flush отправляет пакеты по порядку
+func (b *batcher) flush(batches [][]batchItem) {
+	for _, items := range batches {
+		b.send(context.Background(), items)
+	}
+}
+
+// pending возвращает количество событий, ожидающих отправки
+func (b *batcher) pending() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	n := len(b.buf)
+	for _, items := range b.ready {
+		n += len(items)
+	}
+	return n
+}
+
+// close отправляет накопленные события и останавливает batcher
+func (b *batcher) close(ctx context.Context) error {
+	b.closeOnce.Do(func() {
+		b.mu.Lock()
+		b.closed = true
+		b.cond.Broadcast()
+		b.mu.Unlock()
+		close(b.done)
+	})
+
+	select {
+	case <-b.finished:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// encodeBatch собирает тело пакетного запроса
+func encodeBatch(items []batchItem, format BatchFormat) (body []byte, contentType string) {
+	var buf bytes.Buffer
+	if format == BatchNDJSON {
+		for _, item := range items {
+			buf.Write(item.data)
+			buf.WriteByte('\n')
+		}
+		return buf.Bytes(), "application/x-ndjson"
+	}
+
+	buf.WriteByte('[')
+	for i, item := range items {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		buf.Write(item.data)
+	}
+	buf.WriteByte(']')
+	return buf.Bytes(), "application/json"
+}
+
+// deliverBatch отправляет пакет на bulk endpoint, а при 404 - по одному событию
+func (c *Client) deliverBatch(ctx context.Context, items []batchItem) {
+	defer func() {
+		for _, item := range items {
+			c.stats.pending.done(item.req.Sequence)
+		}
+	}()
+	if !c.batcher.unsupported.Load() {
+		body, contentType := encodeBatch(items, c.batcher.cfg.Format)
+		err := c.postBody(ctx, c.batcher.cfg.Endpoint, contentType, "", body)
+		var statusErr *StatusError
+		switch {
+		case err == nil:
+			c.stats.sent.Add(uint64(len(items)))
+			return
+		case errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusNotFound:
+			c.batcher.unsupported.Store(true)
+		default:
+			for _, item := range items {
+				c.handleFailure(item.req, err)
+			}
+			return
+		}
+	}
+
+	for _, item := range items {
+		if err := c.postBody(ctx, c.endpointPath, "application/json", item.req.EventID, item.data); err != nil {
+			c.handleFailure(item.req, err)
+			continue
+		}
+		c.stats.sent.Add(1)
+	}
+}
@@ -0,0 +1,236 @@
+package logging
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+// batchRecorder запоминает пакеты, пришедшие на bulk endpoint
+type batchRecorder struct {
+	mu      sync.Mutex
+	batches [][]LogRequest
+	single  []LogRequest
+	types   []string
+}
+
+func (br *batchRecorder) handler(t *testing.T, batchStatus int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		br.mu.Lock()
+		defer br.mu.Unlock()
+
+		switch r.URL.Path {
+		case "/logs/batch":
+			if batchStatus != http.StatusOK {
+				w.WriteHeader(batchStatus)
+				return
+			}
+			contentType := r.Header.Get("Content-Type")
+			br.types = append(br.types, contentType)
+			var batch []LogRequest
+			if contentType == "application/x-ndjson" {
+				scanner := bufio.NewScanner(r.Body)
+				for scanner.Scan() {
+					var req LogRequest
+					if err := json.Unmarshal(scanner.Bytes(), &req); err != nil {
+						t.Errorf("invalid NDJSON line: %v", err)
+					}
+					batch = append(batch, req)
+				}
+			} else if err := json.NewDecoder(r.Body).Decode(&batch); err != nil {
+				t.Errorf("invalid JSON array: %v", err)
+			}
+			br.batches = append(br.batches, batch)
+		case "/log":
+			var req LogRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			br.single = append(br.single, req)
+		}
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+func (br *batchRecorder) sizes() []int {
+	br.mu.Lock()
+	defer br.mu.Unlock()
+	sizes := make([]int, len(br.batches))
+	for i, batch := range br.batches {
+		sizes[i] = len(batch)
+	}
+	return sizes
+}
+
+func TestBatch_SizeBoundariesAndOrdering(t *testing.T) {
+	recorder := &batchRecorder{}
+	server := httptest.NewServer(recorder.handler(t, http.StatusOK))
+	defer server.Close()
+
+	client := newClient(server.URL, "test-service", config{
+		Batch: &BatchConfig{MaxEvents: 3, FlushInterval: time.Hour},
+	})
+	for i := 0; i < 7; i++ {
+		if err := client.Info("test_event", strconv.Itoa(i), nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if err := client.Close(context.Background()); err != nil {
+		t.Fatalf("unexpected close error: %v", err)
+	}
+
+	sizes := recorder.sizes()
+	expected := []int{3, 3, 1}
+	if len(sizes) != len(expected) {
+		t.Fatalf("expected batch sizes %v, got %v", expected, sizes)
+	}
+	for i := range expected {
+		if sizes[i] != expected[i] {
+			t.Fatalf("expected batch sizes %v, got %v", expected, sizes)
+		}
+	}
+
+	n := 0
+	for _, batch := range recorder.batches {
+		for _, req := range batch {
+			if req.Message != strconv.Itoa(n) {
+				t.Errorf("expected message %d, got %s", n, req.Message)
+			}
+			if req.Service != "test-service" {
+				t.Errorf("expected service test-service, got %s", req.Service)
+			}
+			n++
+		}
+	}
+	if recorder.types[0] != "application/json" {
+		t.Errorf("expected application/json, got %s", recorder.types[0])
+	}
+	if stats := client.Stats(); stats.Sent != 7 {
+		t.Errorf("expected Sent 7, got %d", stats.Sent)
+	}
+}
+
+func TestBatch_ByteThreshold(t *testing.T) {
+	recorder := &batchRecorder{}
+	server := httptest.NewServer(recorder.handler(t, http.StatusOK))
+	defer server.Close()
+
+	// Фиксированное время дает события одинаковой длины; запас на рост Sequence
+	clock := func() time.Time { return time.Date(2026, 1, 2, 3, 4, 5, 123456789, time.UTC) }
+	probe := newClient(server.URL, "test-service", config{Clock: clock})
+	sample, _ := probe.newRequest(context.Background(), LevelInfo, "test_event", "0", nil)
+	data, _ := json.Marshal(sample)
+	client := newClient(server.URL, "test-service", config{
+		Clock: clock,
+		Batch: &BatchConfig{MaxBytes: 2*(len(data)+1) + len(data)/2, FlushInterval: time.Hour},
+	})
+	for i := 0; i < 5; i++ {
+		client.Info("test_event", strconv.Itoa(i), nil)
+	}
+	client.Close(context.Background())
+
+	sizes := recorder.sizes()
+	expected := []int{2, 2, 1}
+	if len(sizes) != len(expected) {
+		t.Fatalf("expected batch sizes %v, got %v", expected, sizes)
+	}
+	for i := range expected {
+		if sizes[i] != expected[i] {
+			t.Fatalf("expected batch sizes %v, got %v", expected, sizes)
+		}
+	}
+}
+
+func TestBatch_FlushInterval(t *testing.T) {
+	recorder := &batchRecorder{}
+	server := httptest.NewServer(recorder.handler(t, http.StatusOK))
+	defer server.Close()
+
+	client := newClient(server.URL, "test-service", config{
+		Batch: &BatchConfig{MaxEvents: 100, FlushInterval: 20 * time.Millisecond},
+	})
+	defer client.Close(context.Background())
+
+	client.Info("test_event", "0", nil)
+	client.Info("test_event", "1", nil)
+
+	waitFor(t, func() bool { return len(recorder.sizes()) == 1 })
+	if sizes := recorder.sizes(); sizes[0] != 2 {
+		t.Errorf("expected batch of 2 events, got %d", sizes[0])
+	}
+}
+
+func TestBatch_NDJSON(t *testing.T) {
+	recorder := &batchRecorder{}
+	server := httptest.NewServer(recorder.handler(t, http.StatusOK))
+	defer server.Close()
+
+	client := newClient(server.URL, "test-service", config{
+		Batch: &BatchConfig{Format: BatchNDJSON, FlushInterval: time.Hour},
+	})
+	client.Info("test_event", "0", nil)
+	client.Info("test_event", "1", nil)
+	client.Close(context.Background())
+
+	if sizes := recorder.sizes(); len(sizes) != 1 || sizes[0] != 2 {
+		t.Fatalf("expected one batch of 2 events, got %v", sizes)
+	}
+	if recorder.types[0] != "application/x-ndjson" {
+		t.Errorf("expected application/x-ndjson, got %s", recorder.types[0])
+	}
+	if recorder.batches[0][1].Message != "1" {
+		t.Errorf("expected message 1, got %s", recorder.batches[0][1].Message)
+	}
+}
+
+func TestBatch_FallbackOn404(t *testing.T) {
+	recorder := &batchRecorder{}
+	server := httptest.NewServer(recorder.handler(t, http.StatusNotFound))
+	defer server.Close()
+
+	client := newClient(server.URL, "test-service", config{
+		Batch: &BatchConfig{MaxEvents: 2, FlushInterval: time.Hour},
+	})
+	for i := 0; i < 3; i++ {
+		client.Info("test_event", strconv.Itoa(i), nil)
+	}
+	client.Close(context.Background())
+
+	if len(recorder.single) != 3 {
+		t.Fatalf("expected 3 events sent to /log, got %d", len(recorder.single))
+	}
+	for i, req := range recorder.single {
+		if req.Message != strconv.Itoa(i) {
+			t.Errorf("expected message %d, got %s", i, req.Message)
+		}
+	}
+	if !client.batcher.unsupported.Load() {
+		t.Error("expected bulk endpoint to be marked unsupported")
+	}
+}
+
+func TestBatch_WithAsyncQueue(t *testing.T) {
+	recorder := &batchRecorder{}
+	server := httptest.NewServer(recorder.handler(t, http.StatusOK))
+	defer server.Close()
+
+	client := newClient(server.URL, "test-service", config{
+		Async: &AsyncConfig{},
+		Batch: &BatchConfig{MaxEvents: 5, FlushInterval: time.Hour},
+	})
+	for i := 0; i < 10; i++ {
+		client.Info("test_event", strconv.Itoa(i), nil)
+	}
+	client.Close(context.Background())
+
+	if sizes := recorder.sizes(); len(sizes) != 2 {
+		t.Fatalf("expected 2 batches, got %v", sizes)
+	}
+	if stats := client.Stats(); stats.Sent != 10 || stats.Queued != 0 {
+		t.Errorf("expected Sent 10 and Queued 0, got %+v", stats)
+	}
+}
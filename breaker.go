@@ -0,0 +1,234 @@
+package logging
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen возвращается, когда circuit breaker не пропускает отправку.
+// Оборачивает ErrDropped: попытки доставки не было.
+var ErrCircuitOpen = fmt.Errorf("logging circuit breaker is open: %w", ErrDropped)
+
+// BreakerState состояние circuit breaker
+type BreakerState int
+
+const (
+	// BreakerClosed события отправляются как обычно
+	BreakerClosed BreakerState = iota
+	// BreakerOpen отправка не выполняется до истечения OpenTimeout
+	BreakerOpen
+	// BreakerHalfOpen пропускается один пробный запрос
+	BreakerHalfOpen
+)
+
+const (
+	defaultBreakerFailureThreshold = 5
+	defaultBreakerOpenTimeout      = 30 * time.Second
+)
+
+// String возвращает название состояния
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerClosed:
+		return "closed"
+	case BreakerOpen:
+		return "open"
+	case BreakerHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// BreakerConfig настройки circuit breaker
+type BreakerConfig struct {
+	FailureThreshold int           // подряд неудачных отправок до размыкания (по умолчанию 5)
+	OpenTimeout      time.Duration // пауза перед пробным запросом (по умолчанию 30s)
+
+	// Fallback получает события, не отправленные из-за открытого breaker.
+	// Если не задан, события уходят в spool (если он настроен) или
+	// отбрасываются и учитываются в Stats.ShortCircuited.
+	Fallback func(req LogRequest)
+
+	// OnStateChange вызывается при каждой смене состояния
+	OnStateChange func(from, to BreakerState)
+}
+
+// breaker реализует closed / open / half-open автомат
+type breaker struct {
+	cfg BreakerConfig
+	now func() time.Time
+
+	mu       sync.Mutex
+	state    BreakerState
+	failures int
+	openedAt time.Time
+	probing  bool
+	// gen меняется при каждой смене состояния. Отправка, разрешенная в
+	// другом поколении, не влияет на текущее состояние: например, долгий
+	// запрос из closed не должен подменять результат пробного запроса.
+	gen uint64
+}
+
+// newBreaker создает breaker в закрытом состоянии
+func newBreaker(cfg BreakerConfig) *breaker {
+	if cfg.FailureThreshold <= 0 {
+		cfg.FailureThreshold = defaultBreakerFailureThreshold
+	}
+	if cfg.OpenTimeout <= 0 {
+		cfg.OpenTimeout = defaultBreakerOpenTimeout
+	}
+	return &breaker{cfg: cfg, now: time.Now}
+}
+
+// allow сообщает, можно ли выполнить отправку, и возвращает поколение,
+// которое нужно передать в record вместе с результатом
+func (b *breaker) allow() (uint64, error) {
+	b.mu.Lock()
+	changes, err := b.allowLocked()
+	gen := b.gen
+	b.mu.Unlock()
+
+	b.notify(changes)
+	return gen, err
+}
+
+// allowLocked реализует allow. Вызывается под mu.
+func (b *breaker) allowLocked() ([]stateChange, error) {
+	switch b.state {
+	case BreakerOpen:
+		if b.now().Sub(b.openedAt) < b.cfg.OpenTimeout {
+			return nil, ErrCircuitOpen
+		}
+		b.probing = true
+		return b.setState(BreakerHalfOpen), nil
+	case BreakerHalfOpen:
+		if b.probing {
+			return nil, ErrCircuitOpen
+		}
+		b.probing = true
+		return nil, nil
+	default:
+		return nil, nil
+	}
+}
+
+// breakerOutcome результат отправки для breaker
+type breakerOutcome int
+
+const (
+	outcomeSuccess breakerOutcome = iota // logging-service принял событие
+	outcomeFailure                       // logging-service недоступен
+	outcomeUnknown                       // отмена вызывающим или отказ 4xx
+)
+
+// outcomeOf классифицирует результат отправки. Отмена запроса вызывающим
+// и ответы, которые не повторяются, ничего не говорят о доступности
+// сервиса и не меняют состояние breaker.
+func outcomeOf(ctx context.Context, err error) breakerOutcome {
+	switch {
+	case err == nil:
+		return outcomeSuccess
+	case ctx.Err() != nil || !isRetryable(err):
+		return outcomeUnknown
+	default:
+		return outcomeFailure
+	}
+}
+
+// record учитывает результат отправки, разрешенной allow в поколении gen
+func (b *breaker) record(gen uint64, outcome breakerOutcome) {
+	b.mu.Lock()
+	var changes []stateChange
+	switch {
+	case gen != b.gen:
+		// Устаревший результат
+	case outcome == outcomeUnknown:
+		// Состояние сервиса неизвестно; в half-open можно выполнить новую пробу
+		if b.state == BreakerHalfOpen {
+			b.probing = false
+		}
+	case b.state == BreakerHalfOpen:
+		b.probing = false
+		if outcome == outcomeFailure {
+			changes = b.trip()
+		} else {
+			b.failures = 0
+			changes = b.setState(BreakerClosed)
+		}
+	case outcome == outcomeSuccess:
+		b.failures = 0
+	default:
+		b.failures++
+		if b.state == BreakerClosed && b.failures >= b.cfg.FailureThreshold {
+			changes = b.trip()
+		}
+	}
+	b.mu.Unlock()
+
+	b.notify(changes)
+}
+
+// currentState возвращает текущее состояние
+func (b *breaker) currentState() BreakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state
+}
+
+// stateChange переход между состояниями для OnStateChange
+type stateChange struct {
+	from, to BreakerState
+}
+
+// trip размыкает breaker. Вызывается под mu.
+func (b *breaker) trip() []stateChange {
+	b.openedAt = b.now()
+	return b.setState(BreakerOpen)
+}
+
+// setState меняет состояние. Вызывается под mu.
+func (b *breaker) setState(to BreakerState) []stateChange {
+	from := b.state
+	if from == to {
+		return nil
+	}
+	b.state = to
+	b.gen++
+	return []stateChange{{from: from, to: to}}
+}
+
+// notify вызывает OnStateChange вне блокировки, чтобы колбэк мог сам логировать
+func (b *breaker) notify(changes []stateChange) {
+	if b.cfg.OnStateChange == nil {
+		return
+	}
+	for _, change := range changes {
+		b.cfg.OnStateChange(change.from, change.to)
+	}
+}
+
+// BreakerState возвращает состояние circuit breaker клиента.
+// Без настроенного breaker всегда BreakerClosed.
+func (c *Client) BreakerState() BreakerState {
+	if c.breaker == nil {
+		return BreakerClosed
+	}
+	return c.breaker.currentState()
+}
+
+// shortCircuit передает событие в Fallback, в spool или отбрасывает его.
+// Возвращает false, если событие отброшено.
+func (c *Client) shortCircuit(req LogRequest) bool {
+	if c.breaker.cfg.Fallback != nil {
+		c.breaker.cfg.Fallback(req)
+		return true
+	}
+	if c.spoolable(req, ErrCircuitOpen) && c.spoolWrite(req) {
+		return true
+	}
+	c.stats.shortCircuited.Add(1)
+	return false
+}
@@ -0,0 +1,239 @@
+package logging
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestBreaker_TripsAfterConsecutiveFailures(t *testing.T) {
+	var status atomic.Int32
+	status.Store(http.StatusInternalServerError)
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.WriteHeader(int(status.Load()))
+	}))
+	defer server.Close()
+
+	var mu sync.Mutex
+	var transitions []string
+	client := newClient(server.URL, "test-service", config{
+		Breaker: &BreakerConfig{
+			FailureThreshold: 2,
+			OpenTimeout:      time.Minute,
+			OnStateChange: func(from, to BreakerState) {
+				mu.Lock()
+				transitions = append(transitions, from.String()+"->"+to.String())
+				mu.Unlock()
+			},
+		},
+	})
+
+	client.Info("test_event", "1", nil)
+	client.Info("test_event", "2", nil)
+	if client.BreakerState() != BreakerOpen {
+		t.Fatalf("expected breaker to be open, got %s", client.BreakerState())
+	}
+
+	err := client.Info("test_event", "3", nil)
+	if !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("expected ErrCircuitOpen, got %v", err)
+	}
+	if hits.Load() != 2 {
+		t.Errorf("expected 2 requests to reach server, got %d", hits.Load())
+	}
+	if stats := client.Stats(); stats.ShortCircuited != 1 || stats.Failed != 2 {
+		t.Errorf("expected ShortCircuited 1 and Failed 2, got %+v", stats)
+	}
+
+	// Проба после таймаута закрывает breaker
+	status.Store(http.StatusOK)
+	client.breaker.now = func() time.Time { return time.Now().Add(2 * time.Minute) }
+	if err := client.Info("test_event", "4", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if client.BreakerState() != BreakerClosed {
+		t.Errorf("expected breaker to be closed, got %s", client.BreakerState())
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	expected := []string{"closed->open", "open->half-open", "half-open->closed"}
+	if len(transitions) != len(expected) {
+		t.Fatalf("expected transitions %v, got %v", expected, transitions)
+	}
+	for i := range expected {
+		if transitions[i] != expected[i] {
+			t.Errorf("expected transitions %v, got %v", expected, transitions)
+			break
+		}
+	}
+}
+
+func TestBreaker_Fallback(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	var fallback []LogRequest
+	client := newClient(server.URL, "test-service", config{
+		Breaker: &BreakerConfig{
+			FailureThreshold: 1,
+			Fallback:         func(req LogRequest) { fallback = append(fallback, req) },
+		},
+	})
+
+	client.Info("test_event", "fails", nil)
+	client.Info("test_event", "short-circuited", nil)
+
+	if len(fallback) != 1 || fallback[0].Message != "short-circuited" {
+		t.Errorf("expected short-circuited event in fallback, got %v", fallback)
+	}
+	if stats := client.Stats(); stats.ShortCircuited != 0 {
+		t.Errorf("expected no dropped events with fallback, got %d", stats.ShortCircuited)
+	}
+}
+
+func TestBreaker_ClientErrorsDoNotTrip(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	client := newClient(server.URL, "test-service", config{
+		Breaker: &BreakerConfig{FailureThreshold: 1},
+	})
+	client.Info("test_event", "1", nil)
+	client.Info("test_event", "2", nil)
+
+	if client.BreakerState() != BreakerClosed {
+		t.Errorf("expected breaker to stay closed, got %s", client.BreakerState())
+	}
+}
+
+func TestBreaker_HalfOpenAllowsSingleProbe(t *testing.T) {
+	now := time.Now()
+	b := newBreaker(BreakerConfig{FailureThreshold: 1, OpenTimeout: time.Second})
+	b.now = func() time.Time { return now }
+
+	gen, _ := b.allow()
+	b.record(gen, outcomeFailure)
+	if _, err := b.allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected ErrCircuitOpen while open, got %v", err)
+	}
+
+	now = now.Add(2 * time.Second)
+	probe, err := b.allow()
+	if err != nil {
+		t.Fatalf("expected probe to be allowed, got %v", err)
+	}
+	if _, err := b.allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("expected second request during probe to be rejected, got %v", err)
+	}
+
+	// Неудачная проба снова размыкает breaker
+	b.record(probe, outcomeFailure)
+	if b.currentState() != BreakerOpen {
+		t.Errorf("expected breaker to reopen, got %s", b.currentState())
+	}
+	if _, err := b.allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("expected ErrCircuitOpen after failed probe, got %v", err)
+	}
+}
+
+func TestBreaker_IgnoresStaleResultDuringProbe(t *testing.T) {
+	now := time.Now()
+	b := newBreaker(BreakerConfig{FailureThreshold: 1, OpenTimeout: time.Second})
+	b.now = func() time.Time { return now }
+
+	// Медленный запрос разрешен, пока breaker закрыт
+	slow, _ := b.allow()
+	failed, _ := b.allow()
+	b.record(failed, outcomeFailure)
+
+	now = now.Add(2 * time.Second)
+	probe, err := b.allow()
+	if err != nil {
+		t.Fatalf("expected probe to be allowed, got %v", err)
+	}
+
+	// Результат медленного запроса приходит во время пробы
+	b.record(slow, outcomeSuccess)
+	if b.currentState() != BreakerHalfOpen {
+		t.Errorf("expected stale result to be ignored, got %s", b.currentState())
+	}
+	if _, err := b.allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("expected probe to stay in flight, got %v", err)
+	}
+
+	b.record(probe, outcomeSuccess)
+	if b.currentState() != BreakerClosed {
+		t.Errorf("expected successful probe to close breaker, got %s", b.currentState())
+	}
+}
+
+func TestBreaker_SuccessResetsFailureCount(t *testing.T) {
+	b := newBreaker(BreakerConfig{FailureThreshold: 2})
+	b.record(0, outcomeFailure)
+	b.record(0, outcomeSuccess)
+	b.record(0, outcomeFailure)
+	if b.currentState() != BreakerClosed {
+		t.Errorf("expected breaker to stay closed, got %s", b.currentState())
+	}
+}
+
+func TestBreaker_CancelledProbeKeepsHalfOpen(t *testing.T) {
+	var hang atomic.Bool
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if hang.Load() {
+			select {
+			case <-release:
+			case <-r.Context().Done():
+			}
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client := NewClient(server.URL, "test-service",
+		WithCircuitBreaker(BreakerConfig{FailureThreshold: 1, OpenTimeout: 10 * time.Millisecond}))
+	client.Info("test_event", "trips", nil)
+	if client.BreakerState() != BreakerOpen {
+		t.Fatalf("expected breaker to be open, got %s", client.BreakerState())
+	}
+
+	hang.Store(true)
+	time.Sleep(20 * time.Millisecond)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+	defer cancel()
+	if err := client.InfoContext(ctx, "test_event", "cancelled probe", nil); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+
+	if client.BreakerState() != BreakerHalfOpen {
+		t.Errorf("expected cancelled probe to keep breaker half-open, got %s", client.BreakerState())
+	}
+	if _, err := client.breaker.allow(); err != nil {
+		t.Errorf("expected a new probe to be allowed, got %v", err)
+	}
+}
+
+func TestBreaker_UnknownOutcomeKeepsFailureCount(t *testing.T) {
+	b := newBreaker(BreakerConfig{FailureThreshold: 2})
+	b.record(0, outcomeFailure)
+	b.record(0, outcomeUnknown)
+	b.record(0, outcomeFailure)
+	if b.currentState() != BreakerOpen {
+		t.Errorf("expected cancelled sends not to reset failures, got %s", b.currentState())
+	}
+}
@@ -2,17 +2,113 @@ package logging
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"os"
+	"sync/atomic"
 	"time"
 )
 
 // Client HTTP клиент для отправки логов в logging-service
 type Client struct {
-	baseURL     string
-	serviceName string
-	httpClient  *http.Client
+	baseURL         string
+	serviceName     string
+	httpClient      *http.Client
+	endpointPath    string
+	userAgent       string
+	defaultMetadata map[string]interface{}
+	minLevel        *levelVar
+	errorHandler    func(err error, req LogRequest)
+	repanic         bool
+	environment     string
+	instanceID      string
+	now             func() time.Time
+
+	// Поля производных логгеров (With / Named). Все остальные поля -
+	// указатели на общее состояние, поэтому копия клиента делит с
+	// родителем транспорт, очередь и счетчики.
+	fields    map[string]interface{}
+	component string
+
+	queue   *asyncQueue
+	batcher *batcher
+	retry   *RetryPolicy
+	breaker *breaker
+	spool   *spool
+	stats   *clientStats
+}
+
+// config настройки клиента, которые собирают опции NewClient. Нулевое значение
+// соответствует поведению NewClient без опций.
+type config struct {
+	// HTTPClient используемый HTTP клиент (по умолчанию свой с таймаутом 10s)
+	HTTPClient *http.Client
+	// Timeout таймаут HTTP запроса к logging-service
+	Timeout time.Duration
+	// Transport транспорт HTTP клиента
+	Transport http.RoundTripper
+	// EndpointPath путь для отправки одного события (по умолчанию /log)
+	EndpointPath string
+	// UserAgent значение заголовка User-Agent
+	UserAgent string
+	// DefaultMetadata метаданные, добавляемые к каждому событию
+	DefaultMetadata map[string]interface{}
+	// MinLevel минимальный отправляемый уровень (по умолчанию все уровни)
+	MinLevel Level
+	// ErrorHandler вызывается для каждого потерянного события: при ошибке
+	// доставки, в том числе в фоновых воркерах, при вытеснении из
+	// переполненной очереди и при открытом breaker без Fallback и spool.
+	// События, сохраненные в spool, не считаются потерянными. По умолчанию
+	// ошибки пишутся в stderr не чаще раза в 10 секунд; DiscardErrors
+	// отключает вывод.
+	ErrorHandler func(err error, req LogRequest)
+	// Repanic повторяет панику после логирования в RecoverMiddleware и Go
+	Repanic bool
+	// Environment название окружения, например production или staging
+	Environment string
+	// InstanceID ID экземпляра сервиса (по умолчанию случайный на процесс)
+	InstanceID string
+	// Clock источник времени для меток событий (по умолчанию time.Now)
+	Clock func() time.Time
+
+	// Async включает асинхронную доставку через очередь (nil - синхронно)
+	Async *AsyncConfig
+	// Batch включает пакетную отправку на bulk endpoint (nil - по одному)
+	Batch *BatchConfig
+	// Retry включает повторные попытки при временных ошибках (nil - без повторов)
+	Retry *RetryPolicy
+	// Breaker включает circuit breaker вокруг logging-service (nil - выключен)
+	Breaker *BreakerConfig
+	// Spool сохраняет недоставленные события на диск (nil - выключен)
+	Spool *SpoolConfig
+}
+
+// Stats счетчики доставки событий
+type Stats struct {
+	Sent           uint64 // успешно доставлено
+	Failed         uint64 // ошибки доставки
+	Dropped        uint64 // отброшено при переполнении очереди
+	Retried        uint64 // повторных попыток отправки
+	ShortCircuited uint64 // отброшено открытым circuit breaker
+	Spooled        uint64 // сохранено в дисковый spool для досылки
+	Queued         int    // событий в очереди и пакетах, ожидающих отправки
+}
+
+// clientStats атомарные счетчики, общие для клиента и его воркеров
+type clientStats struct {
+	sent           atomic.Uint64
+	failed         atomic.Uint64
+	dropped        atomic.Uint64
+	retried        atomic.Uint64
+	shortCircuited atomic.Uint64
+	spooled        atomic.Uint64
+
+	// pending события, принятые, но еще не доставленные (для Flush и Close)
+	pending pending
 }
 
 // LogRequest структура запроса для отправки логов
@@ -22,47 +118,298 @@ type LogRequest struct {
 	Event    string                 `json:"event"`
 	Message  string                 `json:"message"`
 	Metadata map[string]interface{} `json:"metadata,omitempty"`
+
+	// EventID уникальный ID события (UUIDv7). Не меняется при повторах и
+	// досылке из spool и передается в заголовке Idempotency-Key, поэтому
+	// logging-service может отбросить дубликаты.
+	EventID string `json:"event_id,omitempty"`
+	// Время создания события на клиенте в формате RFC3339Nano. Сохраняет
+	// порядок событий при пакетной отправке, повторах и досылке из spool.
+	Timestamp string `json:"timestamp,omitempty"`
+	// Sequence возрастающий номер события в пределах процесса
+	Sequence    uint64 `json:"sequence,omitempty"`
+	InstanceID  string `json:"instance_id,omitempty"`
+	Hostname    string `json:"hostname,omitempty"`
+	PID         int    `json:"pid,omitempty"`
+	Environment string `json:"environment,omitempty"`
 }
 
 // NewClient создает новый клиент для отправки логов
-func NewClient(baseURL, serviceName string) *Client {
-	return &Client{
-		baseURL:     baseURL,
-		serviceName: serviceName,
-		httpClient: &http.Client{
-			Timeout: 10 * time.Second,
-		},
+func NewClient(baseURL, serviceName string, opts ...Option) *Client {
+	var cfg config
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return newClient(baseURL, serviceName, cfg)
+}
+
+// newClient создает клиент с настройками, собранными из опций
+func newClient(baseURL, serviceName string, cfg config) *Client {
+	c := &Client{
+		baseURL:      baseURL,
+		serviceName:  serviceName,
+		httpClient:   newHTTPClient(cfg),
+		endpointPath: cfg.EndpointPath,
+		userAgent:    cfg.UserAgent,
+		errorHandler: cfg.ErrorHandler,
+		repanic:      cfg.Repanic,
+		environment:  cfg.Environment,
+		instanceID:   cfg.InstanceID,
+		now:          cfg.Clock,
+		stats:        &clientStats{},
+	}
+	if c.endpointPath == "" {
+		c.endpointPath = "/log"
+	}
+	if c.instanceID == "" {
+		c.instanceID = processInstanceID
+	}
+	if c.now == nil {
+		c.now = time.Now
+	}
+	if c.errorHandler == nil {
+		// Часы WithClock нужны для меток событий; ограничитель вывода
+		// работает по реальному времени, иначе с фиксированными часами
+		// после первой строки ошибки подавлялись бы навсегда
+		c.errorHandler = newErrorReporter(os.Stderr, defaultErrorInterval, time.Now).report
+	}
+	if len(cfg.DefaultMetadata) > 0 {
+		c.defaultMetadata = c.mergeMetadata(cfg.DefaultMetadata, nil)
+	}
+	c.minLevel = newLevelVar(cfg.MinLevel)
+	if cfg.Retry != nil {
+		policy := cfg.Retry.withDefaults()
+		c.retry = &policy
+	}
+	if cfg.Breaker != nil {
+		c.breaker = newBreaker(*cfg.Breaker)
+	}
+	if cfg.Spool != nil {
+		c.spool = newSpool(*cfg.Spool, c.replaySend)
+		c.spool.start()
+	}
+	if cfg.Batch != nil {
+		c.batcher = newBatcher(*cfg.Batch, c.deliverBatch)
+	}
+	if cfg.Async != nil {
+		c.queue = newAsyncQueue(*cfg.Async, c.stats, func(req LogRequest) {
+			c.process(context.Background(), req)
+		})
+		c.queue.onDrop = func(req LogRequest) {
+			c.errorHandler(ErrQueueFull, req)
+		}
+	}
+	return c
+}
+
+// newHTTPClient собирает HTTP клиент, не изменяя переданный пользователем
+func newHTTPClient(cfg config) *http.Client {
+	httpClient := &http.Client{
+		Timeout: 10 * time.Second,
+	}
+	if cfg.HTTPClient != nil {
+		clone := *cfg.HTTPClient
+		httpClient = &clone
+	}
+	if cfg.Timeout > 0 {
+		httpClient.Timeout = cfg.Timeout
+	}
+	if cfg.Transport != nil {
+		httpClient.Transport = cfg.Transport
+	}
+	return httpClient
+}
+
+// Stats возвращает текущие счетчики доставки
+func (c *Client) Stats() Stats {
+	s := Stats{
+		Sent:           c.stats.sent.Load(),
+		Failed:         c.stats.failed.Load(),
+		Dropped:        c.stats.dropped.Load(),
+		Retried:        c.stats.retried.Load(),
+		ShortCircuited: c.stats.shortCircuited.Load(),
+		Spooled:        c.stats.spooled.Load(),
+	}
+	if c.queue != nil {
+		s.Queued += c.queue.len()
+	}
+	if c.batcher != nil {
+		s.Queued += c.batcher.pending()
+	}
+	return s
+}
+
+// sendLogContext отправляет лог в logging-service, если уровень события
+// не ниже текущего минимального. Значения из ctx дополняют метаданные,
+// отмена ctx прерывает синхронную отправку. События из очереди и пакетов
+// отправляются независимо от ctx.
+func (c *Client) sendLogContext(ctx context.Context, level Level, event, message string, metadata map[string]interface{}) error {
+	if !c.Enabled(level) {
+		return nil
 	}
+	return c.emit(ctx, level, event, message, metadata)
 }
 
-// sendLog отправляет лог в logging-service
-func (c *Client) sendLog(level, event, message string, metadata map[string]interface{}) error {
+// emit отправляет событие без проверки минимального уровня
+func (c *Client) emit(ctx context.Context, level Level, event, message string, metadata map[string]interface{}) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	payload, err := c.newRequest(ctx, level, event, message, metadata)
+	if err != nil {
+		return err
+	}
+	return c.dispatch(ctx, payload)
+}
+
+// dispatch ставит событие в очередь или отправляет его
+func (c *Client) dispatch(ctx context.Context, payload LogRequest) error {
+	if !c.stats.pending.add(payload.Sequence) {
+		return ErrClientClosed
+	}
+	if c.queue != nil {
+		// Событие сериализуется воркером после возврата из вызова, а
+		// eventMetadata может вернуть карту вызывающего без копирования
+		if payload.Metadata != nil {
+			payload.Metadata = c.mergeMetadata(payload.Metadata, nil)
+		}
+		if err := c.queue.enqueue(payload); err != nil {
+			c.stats.pending.done(payload.Sequence)
+			return err
+		}
+		return nil
+	}
+	return c.process(ctx, payload)
+}
+
+// newRequest собирает событие с метаданными клиента и контекста
+func (c *Client) newRequest(ctx context.Context, level Level, event, message string, metadata map[string]interface{}) (LogRequest, error) {
 	if c.baseURL == "" {
-		return fmt.Errorf("logging client baseURL is empty")
+		return LogRequest{}, ErrEmptyBaseURL
 	}
 
-	payload := LogRequest{
-		Level:    level,
-		Service:  c.serviceName,
-		Event:    event,
-		Message:  message,
-		Metadata: metadata,
+	metadata = c.eventMetadata(ctx, metadata)
+	now := c.now()
+
+	return LogRequest{
+		EventID:     newEventID(now),
+		Level:       level.String(),
+		Service:     c.serviceName,
+		Event:       event,
+		Message:     message,
+		Metadata:    metadata,
+		Timestamp:   now.UTC().Format(time.RFC3339Nano),
+		Sequence:    processSequence.Add(1),
+		InstanceID:  c.instanceID,
+		Hostname:    processHostname,
+		PID:         processID,
+		Environment: c.environment,
+	}, nil
+}
+
+// process передает событие в пакет или отправляет его сразу
+func (c *Client) process(ctx context.Context, payload LogRequest) error {
+	if c.batcher != nil {
+		// Событие в пакете отмечается обработанным в deliverBatch
+		err := c.batcher.add(payload)
+		if err != nil {
+			c.stats.pending.done(payload.Sequence)
+		}
+		return err
 	}
+	defer c.stats.pending.done(payload.Sequence)
+	return c.deliver(ctx, payload)
+}
 
+// deliver выполняет HTTP запрос и обновляет счетчики
+func (c *Client) deliver(ctx context.Context, payload LogRequest) error {
+	err := c.post(ctx, payload)
+	if err != nil {
+		c.handleFailure(payload, err)
+		return err
+	}
+	c.stats.sent.Add(1)
+	return nil
+}
+
+// handleFailure учитывает недоставленное событие и сохраняет его в spool.
+// Обработчик ошибок вызывается, только если событие потеряно: событие,
+// принятое Fallback или spool, еще будет доставлено.
+func (c *Client) handleFailure(payload LogRequest, err error) {
+	if errors.Is(err, ErrCircuitOpen) {
+		if !c.shortCircuit(payload) {
+			c.errorHandler(err, payload)
+		}
+		return
+	}
+	c.stats.failed.Add(1)
+	if c.spoolable(payload, err) && c.spoolWrite(payload) {
+		return
+	}
+	c.errorHandler(err, payload)
+}
+
+// post отправляет одно событие в logging-service
+func (c *Client) post(ctx context.Context, payload LogRequest) error {
 	jsonData, err := json.Marshal(payload)
 	if err != nil {
 		return fmt.Errorf("failed to marshal log payload: %w", err)
 	}
+	return c.postBody(ctx, c.endpointPath, "application/json", payload.EventID, jsonData)
+}
+
+// postBody выполняет POST запрос к logging-service с учетом
+// circuit breaker и политики повторов. Непустой key передается в
+// заголовке Idempotency-Key во всех попытках.
+func (c *Client) postBody(ctx context.Context, path, contentType, key string, body []byte) error {
+	if c.breaker == nil {
+		return c.postWithRetry(ctx, path, contentType, key, body)
+	}
+
+	gen, err := c.breaker.allow()
+	if err != nil {
+		return err
+	}
+	err = c.postWithRetry(ctx, path, contentType, key, body)
+	c.breaker.record(gen, outcomeOf(ctx, err))
+	return err
+}
+
+// postWithRetry выполняет POST запрос, повторяя его по политике
+func (c *Client) postWithRetry(ctx context.Context, path, contentType, key string, body []byte) error {
+	if c.retry == nil {
+		return c.postOnce(ctx, path, contentType, key, body)
+	}
+	return c.retry.do(ctx, c.stats, func() error {
+		return c.postOnce(ctx, path, contentType, key, body)
+	})
+}
+
+// postOnce выполняет одну попытку POST запроса
+func (c *Client) postOnce(ctx context.Context, path, contentType, key string, body []byte) error {
+	url := c.baseURL + path
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build log request: %w", err)
+	}
+	req.Header.Set("Content-Type", contentType)
+	if c.userAgent != "" {
+		req.Header.Set("User-Agent", c.userAgent)
+	}
+	if key != "" {
+		req.Header.Set(IdempotencyKeyHeader, key)
+	}
 
-	url := c.baseURL + "/log"
-	resp, err := c.httpClient.Post(url, "application/json", bytes.NewReader(jsonData))
+	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		return fmt.Errorf("failed to send log to %s: %w", url, err)
 	}
 	defer resp.Body.Close()
+	// Дочитываем тело, чтобы соединение вернулось в пул
+	defer io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return fmt.Errorf("logging service returned status %d", resp.StatusCode)
+		return newStatusError(resp, time.Now())
 	}
 
 	return nil
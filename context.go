@@ -0,0 +1,99 @@
+package logging
+
+import "context"
+
+// contextKey тип ключей для значений, которые клиент извлекает из контекста
+type contextKey int
+
+const (
+	requestIDKey contextKey = iota
+	traceIDKey
+	chatIDKey
+	userIDKey
+	correlationIDKey
+	routeKey
+)
+
+// ContextWithRequestID сохраняет ID запроса в контексте
+func ContextWithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey, requestID)
+}
+
+// RequestIDFromContext возвращает ID запроса из контекста
+func RequestIDFromContext(ctx context.Context) (string, bool) {
+	requestID, ok := ctx.Value(requestIDKey).(string)
+	return requestID, ok
+}
+
+// ContextWithTraceID сохраняет ID трассировки в контексте
+func ContextWithTraceID(ctx context.Context, traceID string) context.Context {
+	return context.WithValue(ctx, traceIDKey, traceID)
+}
+
+// TraceIDFromContext возвращает ID трассировки из контекста
+func TraceIDFromContext(ctx context.Context) (string, bool) {
+	traceID, ok := ctx.Value(traceIDKey).(string)
+	return traceID, ok
+}
+
+// ContextWithChatID сохраняет ID Telegram чата в контексте
+func ContextWithChatID(ctx context.Context, chatID int64) context.Context {
+	return context.WithValue(ctx, chatIDKey, chatID)
+}
+
+// ChatIDFromContext возвращает ID Telegram чата из контекста
+func ChatIDFromContext(ctx context.Context) (int64, bool) {
+	chatID, ok := ctx.Value(chatIDKey).(int64)
+	return chatID, ok
+}
+
+// ContextWithUserID сохраняет ID пользователя в контексте
+func ContextWithUserID(ctx context.Context, userID int64) context.Context {
+	return context.WithValue(ctx, userIDKey, userID)
+}
+
+// UserIDFromContext возвращает ID пользователя из контекста
+func UserIDFromContext(ctx context.Context) (int64, bool) {
+	userID, ok := ctx.Value(userIDKey).(int64)
+	return userID, ok
+}
+
+// ContextWithCorrelationID сохраняет сквозной ID цепочки вызовов между сервисами
+func ContextWithCorrelationID(ctx context.Context, correlationID string) context.Context {
+	return context.WithValue(ctx, correlationIDKey, correlationID)
+}
+
+// CorrelationIDFromContext возвращает сквозной ID цепочки вызовов из контекста
+func CorrelationIDFromContext(ctx context.Context) (string, bool) {
+	correlationID, ok := ctx.Value(correlationIDKey).(string)
+	return correlationID, ok
+}
+
+// contextMetadata собирает метаданные из значений контекста.
+// Возвращает nil, если в контексте ничего нет.
+func contextMetadata(ctx context.Context) map[string]interface{} {
+	var metadata map[string]interface{}
+	set := func(key string, value interface{}) {
+		if metadata == nil {
+			metadata = make(map[string]interface{})
+		}
+		metadata[key] = value
+	}
+
+	if requestID, ok := RequestIDFromContext(ctx); ok && requestID != "" {
+		set("request_id", requestID)
+	}
+	if traceID, ok := TraceIDFromContext(ctx); ok && traceID != "" {
+		set("trace_id", traceID)
+	}
+	if correlationID, ok := CorrelationIDFromContext(ctx); ok && correlationID != "" {
+		set("correlation_id", correlationID)
+	}
+	if chatID, ok := ChatIDFromContext(ctx); ok {
+		set("chat_id", chatID)
+	}
+	if userID, ok := UserIDFromContext(ctx); ok {
+		set("user_id", userID)
+	}
+	return metadata
+}
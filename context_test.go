@@ -0,0 +1,146 @@
+package logging
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestContext_EnrichesMetadata(t *testing.T) {
+	var receivedPayload LogRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&receivedPayload)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	ctx := ContextWithRequestID(context.Background(), "req-1")
+	ctx = ContextWithTraceID(ctx, "trace-1")
+	ctx = ContextWithChatID(ctx, 42)
+	ctx = ContextWithUserID(ctx, 7)
+
+	client := NewClient(server.URL, "test-service")
+	err := client.HTTPRequestContext(ctx, "GET", "/search", 200, 10*time.Millisecond, map[string]interface{}{"user_agent": "test-agent"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if receivedPayload.Event != "http_request" {
+		t.Errorf("expected event http_request, got %s", receivedPayload.Event)
+	}
+	if receivedPayload.Metadata["request_id"] != "req-1" {
+		t.Errorf("expected request_id req-1, got %v", receivedPayload.Metadata["request_id"])
+	}
+	if receivedPayload.Metadata["trace_id"] != "trace-1" {
+		t.Errorf("expected trace_id trace-1, got %v", receivedPayload.Metadata["trace_id"])
+	}
+	if receivedPayload.Metadata["chat_id"] != float64(42) {
+		t.Errorf("expected chat_id 42, got %v", receivedPayload.Metadata["chat_id"])
+	}
+	if receivedPayload.Metadata["user_id"] != float64(7) {
+		t.Errorf("expected user_id 7, got %v", receivedPayload.Metadata["user_id"])
+	}
+	if receivedPayload.Metadata["user_agent"] != "test-agent" {
+		t.Errorf("expected user_agent test-agent, got %v", receivedPayload.Metadata["user_agent"])
+	}
+}
+
+func TestContext_ExplicitMetadataWins(t *testing.T) {
+	var receivedPayload LogRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&receivedPayload)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	ctx := ContextWithRequestID(context.Background(), "from-context")
+	client := NewClient(server.URL, "test-service")
+	client.InfoContext(ctx, "user_action", "user logged in", map[string]interface{}{"request_id": "explicit"})
+
+	if receivedPayload.Metadata["request_id"] != "explicit" {
+		t.Errorf("expected request_id explicit, got %v", receivedPayload.Metadata["request_id"])
+	}
+}
+
+func TestContext_WithoutValuesKeepsMetadataNil(t *testing.T) {
+	var raw map[string]json.RawMessage
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&raw)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-service")
+	client.WarningContext(context.Background(), "slow response", nil)
+
+	if _, ok := raw["metadata"]; ok {
+		t.Errorf("expected metadata to be omitted, got %s", raw["metadata"])
+	}
+}
+
+func TestContext_CancelAbortsSend(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client := NewClient(server.URL, "test-service")
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := client.ErrorContext(ctx, errors.New("boom"), "request failed", nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline exceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected send to be aborted, took %v", elapsed)
+	}
+}
+
+func TestContext_AsyncIgnoresCancel(t *testing.T) {
+	var receivedPayload LogRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&receivedPayload)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-service", WithAsync(AsyncConfig{}))
+	ctx, cancel := context.WithCancel(ContextWithChatID(context.Background(), 99))
+	cancel()
+
+	if err := client.CriticalContext(ctx, "system failure", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	client.Close(context.Background())
+
+	if stats := client.Stats(); stats.Sent != 1 {
+		t.Fatalf("expected event to be delivered, got %+v", stats)
+	}
+	if receivedPayload.Metadata["chat_id"] != float64(99) {
+		t.Errorf("expected chat_id 99, got %v", receivedPayload.Metadata["chat_id"])
+	}
+}
+
+func TestContext_FromContextMissing(t *testing.T) {
+	ctx := context.Background()
+	if _, ok := RequestIDFromContext(ctx); ok {
+		t.Error("expected no request ID")
+	}
+	if _, ok := ChatIDFromContext(ctx); ok {
+		t.Error("expected no chat ID")
+	}
+	if metadata := contextMetadata(ctx); metadata != nil {
+		t.Errorf("expected nil metadata, got %v", metadata)
+	}
+}
@@ -0,0 +1,79 @@
+package logging
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// CorrelationIDHeader заголовок, в котором сквозной ID передается между сервисами
+const CorrelationIDHeader = "X-Correlation-ID"
+
+// Call выполняет вызов другого сервиса и логирует его событием
+// service_communication. Успехом считается nil от call, ошибка попадает
+// в метаданные "error" и возвращается без изменений. Если в ctx нет
+// сквозного ID, создается новый; call получает его в контексте и передает
+// дальше через PropagateCorrelationID.
+func (c *Client) Call(ctx context.Context, targetService, operation string, call func(ctx context.Context) error) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if id, ok := CorrelationIDFromContext(ctx); !ok || id == "" {
+		ctx = ContextWithCorrelationID(ctx, newRandomID())
+	}
+
+	start := time.Now()
+	err := call(ctx)
+	duration := time.Since(start)
+
+	var metadata map[string]interface{}
+	if err != nil {
+		metadata = map[string]interface{}{"error": err.Error()}
+	}
+	// Вызов мог завершиться по отмене ctx, событие при этом все равно нужно
+	c.ServiceCommunicationContext(context.WithoutCancel(ctx), targetService, operation, err == nil, duration, metadata)
+	return err
+}
+
+// correlationTransport добавляет сквозной ID из контекста в заголовки запроса
+type correlationTransport struct {
+	base http.RoundTripper
+}
+
+// PropagateCorrelationID возвращает транспорт, который передает сквозной ID
+// из контекста запроса в заголовке X-Correlation-ID. Предназначен для
+// http.Client, которым сервис вызывает другие сервисы. Если base равен nil,
+// используется http.DefaultTransport.
+func PropagateCorrelationID(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &correlationTransport{base: base}
+}
+
+// RoundTrip реализует http.RoundTripper
+func (t *correlationTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	id, ok := CorrelationIDFromContext(req.Context())
+	if !ok || id == "" || req.Header.Get(CorrelationIDHeader) != "" {
+		return t.base.RoundTrip(req)
+	}
+	// RoundTripper не должен изменять исходный запрос
+	req = req.Clone(req.Context())
+	req.Header.Set(CorrelationIDHeader, id)
+	return t.base.RoundTrip(req)
+}
+
+// CorrelationMiddleware читает сквозной ID из заголовка X-Correlation-ID
+// или создает новый, сохраняет его в контексте запроса и возвращает
+// в заголовке ответа. События, залогированные с этим контекстом,
+// получают метаданные correlation_id.
+func CorrelationMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(CorrelationIDHeader)
+		if id == "" || len(id) > 128 {
+			id = newRandomID()
+		}
+		w.Header().Set(CorrelationIDHeader, id)
+		next.ServeHTTP(w, r.WithContext(ContextWithCorrelationID(r.Context(), id)))
+	})
+}
@@ -0,0 +1,102 @@
+package logging
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCall_PropagatesCorrelationID(t *testing.T) {
+	logServer, received := captureServer(t)
+	client := NewClient(logServer.URL, "gateway-service")
+	searchLogger := NewClient(logServer.URL, "search-service")
+
+	search := httptest.NewServer(CorrelationMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		searchLogger.InfoContext(r.Context(), "search_started", "search started", nil)
+	})))
+	defer search.Close()
+
+	httpClient := &http.Client{Transport: PropagateCorrelationID(nil)}
+	err := client.Call(context.Background(), "search-service", "search", func(ctx context.Context) error {
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, search.URL+"/search", nil)
+		resp, err := httpClient.Do(req)
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	events := received()
+	if len(events) != 2 {
+		t.Fatalf("expected 2 events, got %d", len(events))
+	}
+	server, caller := events[0], events[1]
+	if caller.Event != "service_communication" || caller.Metadata["success"] != true {
+		t.Errorf("expected successful service_communication, got %s %v", caller.Event, caller.Metadata)
+	}
+	id, _ := caller.Metadata["correlation_id"].(string)
+	if id == "" {
+		t.Fatal("expected caller event to have correlation_id")
+	}
+	if server.Metadata["correlation_id"] != id {
+		t.Errorf("expected both ends to share correlation_id %s, got %v", id, server.Metadata["correlation_id"])
+	}
+}
+
+func TestCall_LogsFailure(t *testing.T) {
+	logServer, received := captureServer(t)
+	client := NewClient(logServer.URL, "test-service")
+
+	ctx := ContextWithCorrelationID(context.Background(), "corr-1")
+	callErr := errors.New("connection refused")
+	err := client.Call(ctx, "search-service", "search", func(ctx context.Context) error {
+		if id, _ := CorrelationIDFromContext(ctx); id != "corr-1" {
+			t.Errorf("expected existing correlation ID, got %s", id)
+		}
+		return callErr
+	})
+	if err != callErr {
+		t.Errorf("expected call error to be returned, got %v", err)
+	}
+
+	events := received()
+	if len(events) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(events))
+	}
+	m := events[0].Metadata
+	if events[0].Level != "ERROR" || m["success"] != false {
+		t.Errorf("expected failed ERROR event, got %s %v", events[0].Level, m)
+	}
+	if m["error"] != "connection refused" || m["correlation_id"] != "corr-1" {
+		t.Errorf("unexpected metadata: %v", m)
+	}
+}
+
+func TestCorrelationMiddleware_GeneratesID(t *testing.T) {
+	var fromContext string
+	handler := CorrelationMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fromContext, _ = CorrelationIDFromContext(r.Context())
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	id := rec.Header().Get(CorrelationIDHeader)
+	if len(id) != 32 || id != fromContext {
+		t.Errorf("expected generated ID in response and context, got %q and %q", id, fromContext)
+	}
+
+	rec = httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(CorrelationIDHeader, strings.Repeat("x", 200))
+	handler.ServeHTTP(rec, req)
+	if got := rec.Header().Get(CorrelationIDHeader); len(got) != 32 {
+		t.Errorf("expected oversized ID to be replaced, got %d bytes", len(got))
+	}
+}
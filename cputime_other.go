@@ -0,0 +1,10 @@
+//go:build !unix
+
+package logging
+
+import "time"
+
+// cpuTime недоступно на этой платформе, метрики CPU не отправляются
+func cpuTime() (user, system time.Duration, ok bool) {
+	return 0, 0, false
+}
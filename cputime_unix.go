@@ -0,0 +1,17 @@
+//go:build unix
+
+package logging
+
+import (
+	"syscall"
+	"time"
+)
+
+// cpuTime возвращает процессорное время процесса в режимах пользователя и ядра
+func cpuTime() (user, system time.Duration, ok bool) {
+	var usage syscall.Rusage
+	if err := syscall.Getrusage(syscall.RUSAGE_SELF, &usage); err != nil {
+		return 0, 0, false
+	}
+	return time.Duration(usage.Utime.Nano()), time.Duration(usage.Stime.Nano()), true
+}
@@ -0,0 +1,161 @@
+package logging
+
+import (
+	"context"
+	"sync"
+)
+
+// pending учитывает события, принятые клиентом, но еще не доставленные:
+// отправляемые синхронно, ждущие в очереди и в пакетах. Событие считается
+// обработанным после успешной отправки, окончательной ошибки, сохранения
+// в spool или вытеснения из очереди. События различаются по Sequence.
+type pending struct {
+	mu      sync.Mutex
+	events  map[uint64]struct{}
+	waiters []*pendingWaiter
+	closed  bool
+}
+
+// pendingWaiter ждет обработки событий, принятых до вызова wait
+type pendingWaiter struct {
+	events map[uint64]struct{}
+	done   chan struct{}
+}
+
+// add учитывает новое событие. После close события не принимаются.
+func (p *pending) add(seq uint64) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.closed {
+		return false
+	}
+	if p.events == nil {
+		p.events = make(map[uint64]struct{})
+	}
+	p.events[seq] = struct{}{}
+	return true
+}
+
+// done отмечает событие обработанным
+func (p *pending) done(seq uint64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.events, seq)
+
+	waiters := p.waiters[:0]
+	for _, w := range p.waiters {
+		delete(w.events, seq)
+		if len(w.events) == 0 {
+			close(w.done)
+			continue
+		}
+		waiters = append(waiters, w)
+	}
+	p.waiters = waiters
+}
+
+// close запрещает прием новых событий
+func (p *pending) close() {
+	p.mu.Lock()
+	p.closed = true
+	p.mu.Unlock()
+}
+
+// count возвращает число необработанных событий
+func (p *pending) count() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.events)
+}
+
+// wait ждет обработки событий, принятых до вызова. События, принятые
+// позже, не учитываются, иначе при постоянном потоке событий wait
+// не завершился бы никогда.
+func (p *pending) wait(ctx context.Context) (lost int, err error) {
+	p.mu.Lock()
+	if len(p.events) == 0 {
+		p.mu.Unlock()
+		return 0, nil
+	}
+	w := &pendingWaiter{events: make(map[uint64]struct{}, len(p.events)), done: make(chan struct{})}
+	for seq := range p.events {
+		w.events[seq] = struct{}{}
+	}
+	p.waiters = append(p.waiters, w)
+	p.mu.Unlock()
+
+	select {
+	case <-w.done:
+		return 0, nil
+	case <-ctx.Done():
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for i, other := range p.waiters {
+		if other == w {
+			p.waiters = append(p.waiters[:i], p.waiters[i+1:]...)
+			break
+		}
+	}
+	// Пока ждали блокировку, оставшиеся события могли доставиться
+	if len(w.events) == 0 {
+		return 0, nil
+	}
+	return len(w.events), ctx.Err()
+}
+
+// Flush ждет доставки событий, принятых до вызова, включая очередь и
+// незаполненный пакет, не дольше ctx. События, залогированные во время
+// Flush, не ожидаются. Если ctx истекает раньше, возвращает *DrainError
+// с числом недоставленных событий. Клиент после Flush продолжает работать.
+func (c *Client) Flush(ctx context.Context) error {
+	if c.batcher != nil {
+		c.batcher.startFlush()
+		defer c.batcher.endFlush()
+	}
+	if lost, err := c.stats.pending.wait(ctx); err != nil {
+		return &DrainError{Lost: lost, Err: err}
+	}
+	return nil
+}
+
+// Close перестает принимать события (дальнейшие вызовы возвращают
+// ErrClientClosed), дожидается доставки принятых событий не дольше ctx,
+// останавливает фоновые горутины и закрывает простаивающие соединения.
+// Если ctx истекает раньше, возвращает *DrainError с числом потерянных
+// событий.
+func (c *Client) Close(ctx context.Context) error {
+	c.stats.pending.close()
+
+	err := c.drain(ctx)
+	if c.spool != nil {
+		if spoolErr := c.spool.close(ctx); spoolErr != nil && err == nil {
+			err = spoolErr
+		}
+	}
+	c.httpClient.CloseIdleConnections()
+	return err
+}
+
+// drain останавливает очередь и batcher и ждет отправки событий
+func (c *Client) drain(ctx context.Context) error {
+	if c.queue != nil {
+		if err := c.queue.close(ctx); err != nil {
+			return c.drainError(err)
+		}
+	}
+	if c.batcher != nil {
+		if err := c.batcher.close(ctx); err != nil {
+			return c.drainError(err)
+		}
+	}
+	if lost, err := c.stats.pending.wait(ctx); err != nil {
+		return &DrainError{Lost: lost, Err: err}
+	}
+	return nil
+}
+
+func (c *Client) drainError(err error) error {
+	return &DrainError{Lost: c.stats.pending.count(), Err: err}
+}
@@ -0,0 +1,177 @@
+package logging
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestFlush_DeliversQueuedAndBatchedEvents(t *testing.T) {
+	recorder := &batchRecorder{}
+	server := httptest.NewServer(recorder.handler(t, http.StatusOK))
+	defer server.Close()
+	client := NewClient(server.URL, "test-service",
+		WithAsync(AsyncConfig{}),
+		WithBatching(BatchConfig{MaxEvents: 100, FlushInterval: time.Hour}),
+	)
+	defer client.Close(context.Background())
+
+	for i := 0; i < 5; i++ {
+		client.Info("test_event", "message", nil)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := client.Flush(ctx); err != nil {
+		t.Fatalf("unexpected flush error: %v", err)
+	}
+	if stats := client.Stats(); stats.Sent != 5 || stats.Queued != 0 {
+		t.Errorf("expected 5 sent and nothing queued after Flush, got %+v", stats)
+	}
+
+	// После Flush клиент продолжает принимать события
+	if err := client.Info("test_event", "after flush", nil); err != nil {
+		t.Errorf("unexpected error after flush: %v", err)
+	}
+}
+
+func TestFlush_WaitsForInFlightSend(t *testing.T) {
+	release := make(chan struct{})
+	server, _, _ := blockingServer(t, release)
+	defer server.Close()
+	client := NewClient(server.URL, "test-service")
+
+	go client.Info("test_event", "in flight", nil)
+	waitFor(t, func() bool { return client.stats.pending.count() == 1 })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	err := client.Flush(ctx)
+	var drainErr *DrainError
+	if !errors.As(err, &drainErr) || drainErr.Lost != 1 {
+		t.Fatalf("expected DrainError with 1 lost event, got %v", err)
+	}
+
+	close(release)
+	if err := client.Flush(context.Background()); err != nil {
+		t.Errorf("unexpected flush error: %v", err)
+	}
+}
+
+func TestFlush_IgnoresEventsLoggedDuringFlush(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(2 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	client := NewClient(server.URL, "test-service", WithAsync(AsyncConfig{QueueSize: 10}))
+	defer client.Close(context.Background())
+
+	for i := 0; i < 3; i++ {
+		client.Info("test_event", "before flush", nil)
+	}
+
+	// Постоянный поток событий из других горутин
+	stop := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+				client.Info("test_event", "during flush", nil)
+				time.Sleep(time.Millisecond)
+			}
+		}
+	}()
+	defer func() {
+		close(stop)
+		<-stopped
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := client.Flush(ctx); err != nil {
+		t.Fatalf("expected Flush to finish once earlier events are sent, got %v", err)
+	}
+	if stats := client.Stats(); stats.Sent < 3 {
+		t.Errorf("expected events logged before Flush to be sent, got %+v", stats)
+	}
+}
+
+func TestClose_ReportsLostEvents(t *testing.T) {
+	release := make(chan struct{})
+	server, _, _ := blockingServer(t, release)
+	defer server.Close()
+	defer close(release)
+
+	client := NewClient(server.URL, "test-service", WithAsync(AsyncConfig{QueueSize: 10}))
+	for i := 0; i < 3; i++ {
+		client.Info("test_event", "stuck", nil)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	err := client.Close(ctx)
+
+	var drainErr *DrainError
+	if !errors.As(err, &drainErr) {
+		t.Fatalf("expected DrainError, got %v", err)
+	}
+	if drainErr.Lost != 3 {
+		t.Errorf("expected 3 lost events, got %d", drainErr.Lost)
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline exceeded cause, got %v", err)
+	}
+}
+
+func TestClose_RejectsNewEvents(t *testing.T) {
+	server, received := captureServer(t)
+	client := NewClient(server.URL, "test-service")
+	logger := client.Named("search")
+
+	if err := client.Close(context.Background()); err != nil {
+		t.Fatalf("unexpected close error: %v", err)
+	}
+	if err := client.Info("test_event", "after close", nil); !errors.Is(err, ErrClientClosed) {
+		t.Errorf("expected ErrClientClosed, got %v", err)
+	}
+	if err := logger.Warning("after close", nil); !errors.Is(err, ErrClientClosed) {
+		t.Errorf("expected ErrClientClosed from derived logger, got %v", err)
+	}
+	if len(received()) != 0 {
+		t.Errorf("expected no events after close, got %d", len(received()))
+	}
+	if err := client.Close(context.Background()); err != nil {
+		t.Errorf("expected repeated Close to succeed, got %v", err)
+	}
+}
+
+// idleTransport запоминает вызов CloseIdleConnections
+type idleTransport struct {
+	http.RoundTripper
+	closed atomic.Bool
+}
+
+func (t *idleTransport) CloseIdleConnections() {
+	t.closed.Store(true)
+}
+
+func TestClose_ReleasesIdleConnections(t *testing.T) {
+	server, _ := captureServer(t)
+	transport := &idleTransport{RoundTripper: http.DefaultTransport}
+	client := NewClient(server.URL, "test-service", WithTransport(transport))
+
+	client.Info("test_event", "message", nil)
+	client.Close(context.Background())
+
+	if !transport.closed.Load() {
+		t.Error("expected idle connections to be closed")
+	}
+}
@@ -0,0 +1,53 @@
+package logging
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// defaultErrorInterval минимальный интервал между сообщениями обработчика
+// ошибок по умолчанию
+const defaultErrorInterval = 10 * time.Second
+
+// DiscardErrors обработчик ошибок, который ничего не делает. Передается в
+// WithErrorHandler, чтобы отключить вывод ошибок доставки в stderr.
+func DiscardErrors(error, LogRequest) {}
+
+// errorReporter обработчик ошибок доставки по умолчанию. Пишет не больше
+// одной строки за interval, чтобы недоступный logging-service не засыпал
+// stderr; пропущенные ошибки подсчитываются в следующей строке.
+type errorReporter struct {
+	w        io.Writer
+	interval time.Duration
+	now      func() time.Time
+
+	mu         sync.Mutex
+	last       time.Time
+	suppressed int
+}
+
+func newErrorReporter(w io.Writer, interval time.Duration, now func() time.Time) *errorReporter {
+	return &errorReporter{w: w, interval: interval, now: now}
+}
+
+// report реализует обработчик ошибок для WithErrorHandler
+func (r *errorReporter) report(err error, req LogRequest) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+	if !r.last.IsZero() && now.Sub(r.last) < r.interval {
+		r.suppressed++
+		return
+	}
+	r.last = now
+
+	msg := fmt.Sprintf("logging: failed to deliver %s event %q from %s: %v", req.Level, req.Event, req.Service, err)
+	if r.suppressed > 0 {
+		msg += fmt.Sprintf(" (%d more errors suppressed)", r.suppressed)
+		r.suppressed = 0
+	}
+	fmt.Fprintln(r.w, msg)
+}
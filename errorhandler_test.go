@@ -0,0 +1,151 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestErrorReporter_RateLimited(t *testing.T) {
+	var buf bytes.Buffer
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	reporter := newErrorReporter(&buf, 10*time.Second, func() time.Time { return now })
+	req := LogRequest{Level: "ERROR", Service: "search-service", Event: "error_event"}
+	failure := errors.New("connection refused")
+
+	reporter.report(failure, req)
+	now = now.Add(time.Second)
+	reporter.report(failure, req)
+	reporter.report(failure, req)
+	now = now.Add(10 * time.Second)
+	reporter.report(failure, req)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 lines, got %d: %q", len(lines), buf.String())
+	}
+	expected := `logging: failed to deliver ERROR event "error_event" from search-service: connection refused`
+	if lines[0] != expected {
+		t.Errorf("unexpected line: %s", lines[0])
+	}
+	if !strings.HasSuffix(lines[1], "(2 more errors suppressed)") {
+		t.Errorf("expected suppressed count, got %s", lines[1])
+	}
+}
+
+func TestErrorHandler_DefaultIsSet(t *testing.T) {
+	client := NewClient("http://localhost:8080", "test-service")
+	if client.errorHandler == nil {
+		t.Error("expected default error handler")
+	}
+}
+
+func TestErrorHandler_AsyncFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	var mu sync.Mutex
+	var handled []error
+	client := NewClient(server.URL, "test-service",
+		WithAsync(AsyncConfig{}),
+		WithErrorHandler(func(err error, req LogRequest) {
+			mu.Lock()
+			handled = append(handled, err)
+			mu.Unlock()
+		}),
+	)
+
+	if err := client.Info("test_event", "queued", nil); err != nil {
+		t.Fatalf("unexpected enqueue error: %v", err)
+	}
+	client.Close(context.Background())
+
+	mu.Lock()
+	defer mu.Unlock()
+	var statusErr *StatusError
+	if len(handled) != 1 || !errors.As(handled[0], &statusErr) {
+		t.Errorf("expected StatusError from background worker, got %v", handled)
+	}
+}
+
+func TestErrorHandler_DroppedFromQueue(t *testing.T) {
+	release := make(chan struct{})
+	server, _, _ := blockingServer(t, release)
+	defer server.Close()
+
+	var mu sync.Mutex
+	var dropped []string
+	client := NewClient(server.URL, "test-service",
+		WithAsync(AsyncConfig{QueueSize: 1, Overflow: DropOldest}),
+		WithErrorHandler(func(err error, req LogRequest) {
+			if errors.Is(err, ErrDropped) {
+				mu.Lock()
+				dropped = append(dropped, req.Message)
+				mu.Unlock()
+			}
+		}),
+	)
+
+	// Первое событие занимает воркер, второе вытесняется третьим
+	client.Info("test_event", "1", nil)
+	waitFor(t, func() bool { return client.queue.len() == 0 })
+	client.Info("test_event", "2", nil)
+	client.Info("test_event", "3", nil)
+	close(release)
+	client.Close(context.Background())
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(dropped) != 1 || dropped[0] != "2" {
+		t.Errorf("expected handler to receive dropped event 2, got %v", dropped)
+	}
+}
+
+func TestErrorHandler_OnlyLostEvents(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	var handled []error
+	handler := WithErrorHandler(func(err error, req LogRequest) {
+		handled = append(handled, err)
+	})
+
+	// Событие сохранено в spool и будет дослано
+	spooled := NewClient(server.URL, "test-service", handler,
+		WithSpool(SpoolConfig{Dir: t.TempDir(), ReplayInterval: time.Hour}))
+	spooled.Critical("spooled", nil)
+	spooled.Close(context.Background())
+	if len(handled) != 0 {
+		t.Errorf("expected spooled event not to be reported, got %v", handled)
+	}
+
+	// Открытый breaker с Fallback
+	var fallback int
+	withFallback := NewClient(server.URL, "test-service", handler,
+		WithCircuitBreaker(BreakerConfig{FailureThreshold: 1, OpenTimeout: time.Hour, Fallback: func(LogRequest) { fallback++ }}))
+	withFallback.Info("test_event", "fails and trips", nil)
+	withFallback.Info("test_event", "goes to fallback", nil)
+	if len(handled) != 1 || fallback != 1 {
+		t.Errorf("expected only the failed send to be reported, got %v and %d fallback events", handled, fallback)
+	}
+
+	// Открытый breaker без Fallback теряет событие
+	handled = nil
+	dropping := NewClient(server.URL, "test-service", handler,
+		WithCircuitBreaker(BreakerConfig{FailureThreshold: 1, OpenTimeout: time.Hour}))
+	dropping.Info("test_event", "fails and trips", nil)
+	dropping.Info("test_event", "dropped", nil)
+	if len(handled) != 2 || !errors.Is(handled[1], ErrDropped) {
+		t.Errorf("expected dropped event to be reported with ErrDropped, got %v", handled)
+	}
+}
@@ -0,0 +1,126 @@
+package logging
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+const (
+	// maxErrorChain ограничивает число звеньев цепочки ошибок
+	maxErrorChain = 32
+	// maxStackFrames ограничивает глубину стека в событии
+	maxStackFrames = 32
+)
+
+// packagePrefix префикс имен функций этого пакета, например
+// "github.com/KamnevVladimir/aviabot-shared-logging."
+var packagePrefix = funcPackagePrefix()
+
+func funcPackagePrefix() string {
+	pc, _, _, _ := runtime.Caller(0)
+	name := runtime.FuncForPC(pc).Name()
+	slash := strings.LastIndex(name, "/")
+	dot := strings.Index(name[slash+1:], ".")
+	return name[:slash+1+dot+1]
+}
+
+// errorMetadata описывает ошибку для события error_event: текст, тип,
+// цепочку обернутых ошибок, место вызова логгера, стек и fingerprint
+func errorMetadata(err error) map[string]interface{} {
+	if err == nil {
+		return map[string]interface{}{"error": "<nil>"}
+	}
+
+	chain := errorChain(err)
+	frames := callerFrames()
+	metadata := map[string]interface{}{
+		"error":       err.Error(),
+		"error_type":  fmt.Sprintf("%T", err),
+		"error_chain": chain,
+	}
+	var function string
+	if len(frames) > 0 {
+		function = frames[0].Function
+		metadata["source"] = frames[0].File + ":" + strconv.Itoa(frames[0].Line)
+		metadata["stack"] = formatStack(frames)
+	}
+	metadata["fingerprint"] = errorFingerprint(chain, function)
+	return metadata
+}
+
+// errorChain разворачивает ошибку в список звеньев в порядке обхода в глубину.
+// Учитываются и Unwrap() error, и Unwrap() []error (errors.Join, fmt.Errorf с
+// несколькими %w).
+func errorChain(err error) []map[string]interface{} {
+	var chain []map[string]interface{}
+	var walk func(err error)
+	walk = func(err error) {
+		if err == nil || len(chain) >= maxErrorChain {
+			return
+		}
+		chain = append(chain, map[string]interface{}{
+			"message": err.Error(),
+			"type":    fmt.Sprintf("%T", err),
+		})
+		switch e := err.(type) {
+		case interface{ Unwrap() error }:
+			walk(e.Unwrap())
+		case interface{ Unwrap() []error }:
+			for _, child := range e.Unwrap() {
+				walk(child)
+			}
+		}
+	}
+	walk(err)
+	return chain
+}
+
+// callerFrames возвращает стек начиная с первого вызова вне пакета
+func callerFrames() []runtime.Frame {
+	pcs := make([]uintptr, maxStackFrames+16)
+	n := runtime.Callers(2, pcs)
+	iter := runtime.CallersFrames(pcs[:n])
+
+	var frames []runtime.Frame
+	for {
+		frame, more := iter.Next()
+		internal := strings.HasPrefix(frame.Function, packagePrefix) && !strings.HasSuffix(frame.File, "_test.go")
+		if !internal || len(frames) > 0 {
+			frames = append(frames, frame)
+		}
+		if !more || len(frames) >= maxStackFrames {
+			return frames
+		}
+	}
+}
+
+// formatStack форматирует стек как debug.Stack: функция и file:line на строке ниже
+func formatStack(frames []runtime.Frame) string {
+	var b strings.Builder
+	for _, frame := range frames {
+		b.WriteString(frame.Function)
+		b.WriteString("\n\t")
+		b.WriteString(frame.File)
+		b.WriteByte(':')
+		b.WriteString(strconv.Itoa(frame.Line))
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// errorFingerprint группирует одинаковые ошибки. Учитываются типы звеньев
+// цепочки и функция, вызвавшая логгер; тексты ошибок и номера строк не
+// учитываются, так как меняются от вызова к вызову и от релиза к релизу.
+func errorFingerprint(chain []map[string]interface{}, function string) string {
+	h := sha256.New()
+	h.Write([]byte(function))
+	for _, link := range chain {
+		h.Write([]byte{0})
+		h.Write([]byte(link["type"].(string)))
+	}
+	return hex.EncodeToString(h.Sum(nil)[:8])
+}
@@ -0,0 +1,103 @@
+package logging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"strings"
+	"testing"
+)
+
+func TestError_RecordsChain(t *testing.T) {
+	server, received := captureServer(t)
+	client := NewClient(server.URL, "test-service")
+
+	pathErr := &fs.PathError{Op: "open", Path: "/etc/config", Err: fs.ErrNotExist}
+	err := fmt.Errorf("load config: %w", errors.Join(pathErr, errors.New("fallback failed")))
+	client.Error(err, "startup failed", nil)
+
+	events := received()
+	if len(events) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(events))
+	}
+	m := events[0].Metadata
+	if m["error"] != err.Error() || m["error_type"] != "*fmt.wrapError" {
+		t.Errorf("unexpected error metadata: %v %v", m["error"], m["error_type"])
+	}
+
+	chain, ok := m["error_chain"].([]interface{})
+	if !ok {
+		t.Fatalf("expected error_chain list, got %T", m["error_chain"])
+	}
+	var types []string
+	for _, link := range chain {
+		types = append(types, link.(map[string]interface{})["type"].(string))
+	}
+	expected := []string{"*fmt.wrapError", "*errors.joinError", "*fs.PathError", "*errors.errorString", "*errors.errorString"}
+	if strings.Join(types, ",") != strings.Join(expected, ",") {
+		t.Errorf("expected chain %v, got %v", expected, types)
+	}
+	if msg := chain[2].(map[string]interface{})["message"]; msg != pathErr.Error() {
+		t.Errorf("expected path error message, got %v", msg)
+	}
+}
+
+func TestError_RecordsCallSite(t *testing.T) {
+	server, received := captureServer(t)
+	client := NewClient(server.URL, "test-service").Named("search")
+
+	client.ErrorContext(context.Background(), errors.New("timeout"), "search failed", nil)
+
+	m := received()[0].Metadata
+	source, _ := m["source"].(string)
+	if !strings.Contains(source, "errorinfo_test.go:") {
+		t.Errorf("expected source in errorinfo_test.go, got %q", source)
+	}
+	stack, _ := m["stack"].(string)
+	if !strings.HasPrefix(stack, packagePrefix+"TestError_RecordsCallSite") {
+		t.Errorf("expected stack to start at the caller, got %q", stack)
+	}
+	if strings.Contains(stack, "ErrorContext") {
+		t.Errorf("expected logger frames to be skipped, got %q", stack)
+	}
+}
+
+func TestError_FingerprintIsStable(t *testing.T) {
+	server, received := captureServer(t)
+	client := NewClient(server.URL, "test-service")
+
+	logSearchError := func(err error) {
+		client.Error(err, "search failed", nil)
+	}
+	logSearchError(fmt.Errorf("search %d: %w", 1, fs.ErrNotExist))
+	logSearchError(fmt.Errorf("search %d: %w", 2, fs.ErrNotExist))
+	logSearchError(fmt.Errorf("search %d: %w", 3, &fs.PathError{Op: "open", Path: "x", Err: fs.ErrNotExist}))
+
+	events := received()
+	if len(events) != 3 {
+		t.Fatalf("expected 3 events, got %d", len(events))
+	}
+	first, _ := events[0].Metadata["fingerprint"].(string)
+	if len(first) != 16 {
+		t.Fatalf("expected 16 hex fingerprint, got %q", first)
+	}
+	if events[1].Metadata["fingerprint"] != first {
+		t.Errorf("expected same fingerprint for the same error, got %v and %v", first, events[1].Metadata["fingerprint"])
+	}
+	if events[2].Metadata["fingerprint"] == first {
+		t.Errorf("expected different fingerprint for a different error chain")
+	}
+}
+
+func TestError_NilError(t *testing.T) {
+	server, received := captureServer(t)
+	client := NewClient(server.URL, "test-service")
+
+	if err := client.Error(nil, "unexpected", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if m := received()[0].Metadata; m["error"] != "<nil>" {
+		t.Errorf("expected <nil> error, got %v", m["error"])
+	}
+}
@@ -0,0 +1,73 @@
+package logging
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrEmptyBaseURL возвращается, если клиент создан без адреса logging-service
+	ErrEmptyBaseURL = errors.New("logging client baseURL is empty")
+	// ErrClientClosed возвращается при отправке события после Close
+	ErrClientClosed = errors.New("logging client is closed")
+	// ErrDropped событие отброшено клиентом без попытки доставки.
+	// Более конкретные ошибки, ErrQueueFull и ErrCircuitOpen, оборачивают ее.
+	ErrDropped = errors.New("logging event dropped")
+	// ErrQueueFull очередь асинхронного клиента заполнена (режим DropNewest)
+	ErrQueueFull = fmt.Errorf("logging queue is full: %w", ErrDropped)
+)
+
+// maxStatusBodyBytes сколько байт тела ответа сохраняется в StatusError
+const maxStatusBodyBytes = 512
+
+// StatusError возвращается, если logging-service ответил статусом не 2xx
+type StatusError struct {
+	StatusCode int
+	// Body начало тела ответа, не больше 512 байт
+	Body string
+	// RetryAfter значение заголовка Retry-After, если он был
+	RetryAfter time.Duration
+}
+
+func (e *StatusError) Error() string {
+	if e.Body == "" {
+		return fmt.Sprintf("logging service returned status %d", e.StatusCode)
+	}
+	return fmt.Sprintf("logging service returned status %d: %s", e.StatusCode, e.Body)
+}
+
+// Retryable сообщает, имеет ли смысл повторить запрос: 429 и 5xx
+// считаются временными, остальные ответы - отказом принять событие
+func (e *StatusError) Retryable() bool {
+	return e.StatusCode == http.StatusTooManyRequests || e.StatusCode >= 500
+}
+
+// newStatusError читает начало тела ответа и собирает StatusError
+func newStatusError(resp *http.Response, now time.Time) *StatusError {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxStatusBodyBytes))
+	return &StatusError{
+		StatusCode: resp.StatusCode,
+		Body:       strings.TrimSpace(strings.ToValidUTF8(string(body), "")),
+		RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"), now),
+	}
+}
+
+// DrainError возвращают Flush и Close, если контекст истек раньше, чем
+// были доставлены все принятые события
+type DrainError struct {
+	Lost int   // события, не доставленные к истечению контекста
+	Err  error // ошибка контекста
+}
+
+func (e *DrainError) Error() string {
+	return fmt.Sprintf("logging client: %d events not delivered: %v", e.Lost, e.Err)
+}
+
+// Unwrap позволяет проверять причину через errors.Is, например context.DeadlineExceeded
+func (e *DrainError) Unwrap() error {
+	return e.Err
+}
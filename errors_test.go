@@ -0,0 +1,79 @@
+package logging
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestErrors_EmptyBaseURL(t *testing.T) {
+	client := NewClient("", "test-service")
+	if err := client.Info("test_event", "test message", nil); !errors.Is(err, ErrEmptyBaseURL) {
+		t.Errorf("expected ErrEmptyBaseURL, got %v", err)
+	}
+}
+
+func TestErrors_DropErrorsWrapErrDropped(t *testing.T) {
+	if !errors.Is(ErrQueueFull, ErrDropped) {
+		t.Error("expected ErrQueueFull to match ErrDropped")
+	}
+	if !errors.Is(ErrCircuitOpen, ErrDropped) {
+		t.Error("expected ErrCircuitOpen to match ErrDropped")
+	}
+}
+
+func TestStatusError_CarriesResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "7")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte("  database is down\n"))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-service")
+	err := client.Info("test_event", "test message", nil)
+
+	var statusErr *StatusError
+	if !errors.As(err, &statusErr) {
+		t.Fatalf("expected *StatusError, got %v", err)
+	}
+	if statusErr.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("expected status 503, got %d", statusErr.StatusCode)
+	}
+	if statusErr.Body != "database is down" {
+		t.Errorf("expected body excerpt, got %q", statusErr.Body)
+	}
+	if statusErr.RetryAfter.Seconds() != 7 {
+		t.Errorf("expected Retry-After 7s, got %v", statusErr.RetryAfter)
+	}
+	if !statusErr.Retryable() {
+		t.Error("expected 503 to be retryable")
+	}
+	if err.Error() != "logging service returned status 503: database is down" {
+		t.Errorf("unexpected error text: %s", err.Error())
+	}
+}
+
+func TestStatusError_TruncatesBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		// Многобайтовые символы не должны обрезаться посередине
+		w.Write([]byte("a" + strings.Repeat("я", 1000)))
+	}))
+	defer server.Close()
+
+	err := NewClient(server.URL, "test-service").Info("test_event", "test message", nil)
+
+	var statusErr *StatusError
+	if !errors.As(err, &statusErr) {
+		t.Fatalf("expected *StatusError, got %v", err)
+	}
+	if statusErr.Body != "a"+strings.Repeat("я", maxStatusBodyBytes/2-1) {
+		t.Errorf("expected excerpt of %d bytes without broken rune, got %d bytes", maxStatusBodyBytes-1, len(statusErr.Body))
+	}
+	if statusErr.Retryable() {
+		t.Error("expected 400 not to be retryable")
+	}
+}
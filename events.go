@@ -1,66 +1,124 @@
 package logging
 
 import (
+	"context"
 	"fmt"
 	"time"
 )
 
 // ServiceStart логирует запуск сервиса
 func (c *Client) ServiceStart(version, message string) error {
+	return c.ServiceStartContext(context.Background(), version, message)
+}
+
+// ServiceStartContext логирует запуск сервиса с учетом контекста запроса
+func (c *Client) ServiceStartContext(ctx context.Context, version, message string) error {
+	if !c.Enabled(LevelInfo) {
+		return nil
+	}
 	metadata := map[string]interface{}{
 		"version": version,
 	}
-	return c.sendLog("INFO", "service_start", message, metadata)
+	return c.sendLogContext(ctx, LevelInfo, "service_start", message, metadata)
 }
 
 // ServiceStop логирует остановку сервиса
 func (c *Client) ServiceStop(uptime time.Duration, message string) error {
+	return c.ServiceStopContext(context.Background(), uptime, message)
+}
+
+// ServiceStopContext логирует остановку сервиса с учетом контекста запроса
+func (c *Client) ServiceStopContext(ctx context.Context, uptime time.Duration, message string) error {
+	if !c.Enabled(LevelInfo) {
+		return nil
+	}
 	metadata := map[string]interface{}{
 		"uptime_seconds": uptime.Seconds(),
 	}
-	return c.sendLog("INFO", "service_stop", message, metadata)
+	return c.sendLogContext(ctx, LevelInfo, "service_stop", message, metadata)
 }
 
 // Health логирует состояние здоровья сервиса
 func (c *Client) Health(status, message string, metadata map[string]interface{}) error {
+	return c.HealthContext(context.Background(), status, message, metadata)
+}
+
+// HealthContext логирует состояние здоровья сервиса с учетом контекста запроса
+func (c *Client) HealthContext(ctx context.Context, status, message string, metadata map[string]interface{}) error {
+	if !c.Enabled(LevelInfo) {
+		return nil
+	}
 	baseMetadata := map[string]interface{}{
 		"status": status,
 	}
 	finalMetadata := c.mergeMetadata(baseMetadata, metadata)
-	return c.sendLog("INFO", "health_check", message, finalMetadata)
+	return c.sendLogContext(ctx, LevelInfo, "health_check", message, finalMetadata)
 }
 
-// Error логирует ошибки
+// Error логирует ошибку с цепочкой обернутых ошибок, местом вызова, стеком и fingerprint
 func (c *Client) Error(err error, message string, metadata map[string]interface{}) error {
-	baseMetadata := map[string]interface{}{
-		"error": err.Error(),
+	return c.ErrorContext(context.Background(), err, message, metadata)
+}
+
+// ErrorContext логирует ошибки с учетом контекста запроса
+func (c *Client) ErrorContext(ctx context.Context, err error, message string, metadata map[string]interface{}) error {
+	if !c.Enabled(LevelError) {
+		return nil
 	}
-	finalMetadata := c.mergeMetadata(baseMetadata, metadata)
-	return c.sendLog("ERROR", "error_event", message, finalMetadata)
+	finalMetadata := c.mergeMetadata(errorMetadata(err), metadata)
+	return c.sendLogContext(ctx, LevelError, "error_event", message, finalMetadata)
 }
 
 // Warning логирует предупреждения
 func (c *Client) Warning(message string, metadata map[string]interface{}) error {
-	return c.sendLog("WARNING", "warning_event", message, metadata)
+	return c.WarningContext(context.Background(), message, metadata)
+}
+
+// WarningContext логирует предупреждения с учетом контекста запроса
+func (c *Client) WarningContext(ctx context.Context, message string, metadata map[string]interface{}) error {
+	return c.sendLogContext(ctx, LevelWarning, "warning_event", message, metadata)
 }
 
 // Info логирует информационные события
 func (c *Client) Info(event, message string, metadata map[string]interface{}) error {
-	return c.sendLog("INFO", event, message, metadata)
+	return c.InfoContext(context.Background(), event, message, metadata)
+}
+
+// InfoContext логирует информационные события с учетом контекста запроса
+func (c *Client) InfoContext(ctx context.Context, event, message string, metadata map[string]interface{}) error {
+	return c.sendLogContext(ctx, LevelInfo, event, message, metadata)
 }
 
 // Critical логирует критические события
 func (c *Client) Critical(message string, metadata map[string]interface{}) error {
-	return c.sendLog("CRITICAL", "critical_event", message, metadata)
+	return c.CriticalContext(context.Background(), message, metadata)
+}
+
+// CriticalContext логирует критические события с учетом контекста запроса
+func (c *Client) CriticalContext(ctx context.Context, message string, metadata map[string]interface{}) error {
+	return c.sendLogContext(ctx, LevelCritical, "critical_event", message, metadata)
 }
 
 // Debug логирует отладочную информацию
 func (c *Client) Debug(message string, metadata map[string]interface{}) error {
-	return c.sendLog("DEBUG", "debug_event", message, metadata)
+	return c.DebugContext(context.Background(), message, metadata)
+}
+
+// DebugContext логирует отладочную информацию с учетом контекста запроса
+func (c *Client) DebugContext(ctx context.Context, message string, metadata map[string]interface{}) error {
+	return c.sendLogContext(ctx, LevelDebug, "debug_event", message, metadata)
 }
 
 // HTTPRequest логирует HTTP запросы
 func (c *Client) HTTPRequest(method, path string, statusCode int, duration time.Duration, metadata map[string]interface{}) error {
+	return c.HTTPRequestContext(context.Background(), method, path, statusCode, duration, metadata)
+}
+
+// HTTPRequestContext логирует HTTP запросы с учетом контекста запроса
+func (c *Client) HTTPRequestContext(ctx context.Context, method, path string, statusCode int, duration time.Duration, metadata map[string]interface{}) error {
+	if !c.Enabled(LevelInfo) {
+		return nil
+	}
 	baseMetadata := map[string]interface{}{
 		"method":       method,
 		"path":         path,
@@ -69,11 +127,19 @@ func (c *Client) HTTPRequest(method, path string, statusCode int, duration time.
 	}
 	finalMetadata := c.mergeMetadata(baseMetadata, metadata)
 	message := fmt.Sprintf("%s %s - %d", method, path, statusCode)
-	return c.sendLog("INFO", "http_request", message, finalMetadata)
+	return c.sendLogContext(ctx, LevelInfo, "http_request", message, finalMetadata)
 }
 
 // ExternalAPI логирует вызовы внешних API
 func (c *Client) ExternalAPI(apiName, endpoint string, statusCode int, duration time.Duration, metadata map[string]interface{}) error {
+	return c.ExternalAPIContext(context.Background(), apiName, endpoint, statusCode, duration, metadata)
+}
+
+// ExternalAPIContext логирует вызовы внешних API с учетом контекста запроса
+func (c *Client) ExternalAPIContext(ctx context.Context, apiName, endpoint string, statusCode int, duration time.Duration, metadata map[string]interface{}) error {
+	if !c.Enabled(LevelInfo) {
+		return nil
+	}
 	baseMetadata := map[string]interface{}{
 		"api_name":     apiName,
 		"endpoint":     endpoint,
@@ -82,11 +148,24 @@ func (c *Client) ExternalAPI(apiName, endpoint string, statusCode int, duration
 	}
 	finalMetadata := c.mergeMetadata(baseMetadata, metadata)
 	message := fmt.Sprintf("API call to %s", apiName)
-	return c.sendLog("INFO", "external_api", message, finalMetadata)
+	return c.sendLogContext(ctx, LevelInfo, "external_api", message, finalMetadata)
 }
 
 // ServiceCommunication логирует взаимодействие между сервисами
 func (c *Client) ServiceCommunication(targetService, operation string, success bool, duration time.Duration, metadata map[string]interface{}) error {
+	return c.ServiceCommunicationContext(context.Background(), targetService, operation, success, duration, metadata)
+}
+
+// ServiceCommunicationContext логирует взаимодействие между сервисами с учетом контекста запроса
+func (c *Client) ServiceCommunicationContext(ctx context.Context, targetService, operation string, success bool, duration time.Duration, metadata map[string]interface{}) error {
+	level := LevelInfo
+	if !success {
+		level = LevelError
+	}
+	if !c.Enabled(level) {
+		return nil
+	}
+
 	baseMetadata := map[string]interface{}{
 		"target_service": targetService,
 		"operation":      operation,
@@ -95,11 +174,6 @@ func (c *Client) ServiceCommunication(targetService, operation string, success b
 	}
 	finalMetadata := c.mergeMetadata(baseMetadata, metadata)
 	message := fmt.Sprintf("Communication with %s: %s", targetService, operation)
-	
-	level := "INFO"
-	if !success {
-		level = "ERROR"
-	}
-	
-	return c.sendLog(level, "service_communication", message, finalMetadata)
+
+	return c.sendLogContext(ctx, level, "service_communication", message, finalMetadata)
 }
@@ -9,7 +9,8 @@ import (
 
 // Пример интеграции logging клиента в микросервис
 func main() {
-	// Инициализация клиента
+	// Инициализация клиента. Ошибки доставки по умолчанию пишутся в stderr,
+	// поэтому возвращаемые ошибки в примере не проверяются.
 	logger := logging.NewClient("http://logging-service:8080", "example-service")
 
 	// Service lifecycle
@@ -0,0 +1,46 @@
+package logging
+
+import "context"
+
+// With возвращает производный логгер, который добавляет fields к
+// метаданным каждого события. Метаданные вызова имеют приоритет над fields.
+// Производный логгер использует транспорт, очередь и счетчики родителя.
+func (c *Client) With(fields map[string]interface{}) *Client {
+	child := *c
+	child.fields = c.mergeMetadata(c.fields, fields)
+	return &child
+}
+
+// Named возвращает производный логгер для подкомпонента. Имя попадает
+// в метаданные "component"; вложенные имена соединяются точкой.
+func (c *Client) Named(name string) *Client {
+	child := *c
+	if c.component == "" {
+		child.component = name
+	} else if name != "" {
+		child.component = c.component + "." + name
+	}
+	return &child
+}
+
+// eventMetadata собирает итоговые метаданные события. Приоритет по
+// возрастанию: метаданные по умолчанию, компонент, поля With, значения
+// контекста, метаданные вызова. Если добавлять нечего, metadata
+// возвращается без копирования.
+func (c *Client) eventMetadata(ctx context.Context, metadata map[string]interface{}) map[string]interface{} {
+	ctxMetadata := contextMetadata(ctx)
+	if c.defaultMetadata == nil && c.component == "" && c.fields == nil && ctxMetadata == nil {
+		return metadata
+	}
+
+	result := c.mergeMetadata(c.defaultMetadata, nil)
+	if c.component != "" {
+		result["component"] = c.component
+	}
+	for _, layer := range []map[string]interface{}{c.fields, ctxMetadata, metadata} {
+		for k, v := range layer {
+			result[k] = v
+		}
+	}
+	return result
+}
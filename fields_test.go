@@ -0,0 +1,131 @@
+package logging
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+// captureServer запоминает все полученные события
+func captureServer(t *testing.T) (*httptest.Server, func() []LogRequest) {
+	t.Helper()
+	var mu sync.Mutex
+	var received []LogRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload LogRequest
+		json.NewDecoder(r.Body).Decode(&payload)
+		mu.Lock()
+		received = append(received, payload)
+		mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+	return server, func() []LogRequest {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]LogRequest(nil), received...)
+	}
+}
+
+func TestWith_BindsFields(t *testing.T) {
+	server, received := captureServer(t)
+
+	client := NewClient(server.URL, "test-service")
+	logger := client.With(map[string]interface{}{"chat_id": 42, "handler": "search"})
+	logger = logger.With(map[string]interface{}{"request_id": "req-1", "handler": "search_v2"})
+
+	logger.Info("user_action", "search requested", map[string]interface{}{"request_id": "override"})
+
+	events := received()
+	if len(events) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(events))
+	}
+	metadata := events[0].Metadata
+	if metadata["chat_id"] != float64(42) {
+		t.Errorf("expected chat_id 42, got %v", metadata["chat_id"])
+	}
+	if metadata["handler"] != "search_v2" {
+		t.Errorf("expected handler search_v2, got %v", metadata["handler"])
+	}
+	if metadata["request_id"] != "override" {
+		t.Errorf("expected call metadata to win, got %v", metadata["request_id"])
+	}
+}
+
+func TestWith_DoesNotAffectParent(t *testing.T) {
+	server, received := captureServer(t)
+
+	client := NewClient(server.URL, "test-service")
+	client.With(map[string]interface{}{"chat_id": 42})
+	client.Warning("parent event", nil)
+
+	if events := received(); events[0].Metadata != nil {
+		t.Errorf("expected parent metadata to stay empty, got %v", events[0].Metadata)
+	}
+}
+
+func TestNamed_NestsComponents(t *testing.T) {
+	server, received := captureServer(t)
+
+	client := NewClient(server.URL, "test-service")
+	client.Named("search").Named("cache").Debug("cache miss", nil)
+	client.Named("search").Info("search_done", "done", map[string]interface{}{"component": "explicit"})
+
+	events := received()
+	if events[0].Metadata["component"] != "search.cache" {
+		t.Errorf("expected component search.cache, got %v", events[0].Metadata["component"])
+	}
+	if events[1].Metadata["component"] != "explicit" {
+		t.Errorf("expected call metadata to win, got %v", events[1].Metadata["component"])
+	}
+}
+
+func TestWith_ContextAndDefaultsPriority(t *testing.T) {
+	server, received := captureServer(t)
+
+	client := NewClient(server.URL, "test-service", WithDefaultMetadata(map[string]interface{}{
+		"region":     "eu",
+		"request_id": "default",
+	}))
+	logger := client.With(map[string]interface{}{"request_id": "bound"})
+
+	ctx := ContextWithRequestID(context.Background(), "from-context")
+	logger.InfoContext(ctx, "user_action", "message", nil)
+	logger.Info("user_action", "message", nil)
+
+	events := received()
+	if events[0].Metadata["request_id"] != "from-context" {
+		t.Errorf("expected context to win over bound fields, got %v", events[0].Metadata["request_id"])
+	}
+	if events[1].Metadata["request_id"] != "bound" {
+		t.Errorf("expected bound field to win over defaults, got %v", events[1].Metadata["request_id"])
+	}
+	if events[1].Metadata["region"] != "eu" {
+		t.Errorf("expected default region eu, got %v", events[1].Metadata["region"])
+	}
+}
+
+func TestWith_SharesQueueAndStats(t *testing.T) {
+	server, received := captureServer(t)
+
+	client := NewClient(server.URL, "test-service", WithAsync(AsyncConfig{}))
+	child := client.With(map[string]interface{}{"chat_id": 1}).Named("poller")
+
+	if child.queue != client.queue || child.httpClient != client.httpClient {
+		t.Fatal("expected derived logger to share queue and transport")
+	}
+
+	child.Info("test_event", "from child", nil)
+	client.Info("test_event", "from parent", nil)
+	client.Close(context.Background())
+
+	if len(received()) != 2 {
+		t.Errorf("expected 2 events, got %d", len(received()))
+	}
+	if child.Stats().Sent != 2 {
+		t.Errorf("expected shared stats, got %+v", child.Stats())
+	}
+}
@@ -0,0 +1,167 @@
+package logging
+
+import (
+	"context"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultHealthInterval     = time.Minute
+	defaultHealthCheckTimeout = 5 * time.Second
+)
+
+// HealthStatus итоговое состояние сервиса в событии health_check
+type HealthStatus string
+
+const (
+	HealthHealthy   HealthStatus = "healthy"   // все проверки прошли
+	HealthDegraded  HealthStatus = "degraded"  // не прошла некритичная проверка
+	HealthUnhealthy HealthStatus = "unhealthy" // не прошла критичная проверка
+)
+
+// HealthCheck проверка зависимости сервиса, например ping базы данных
+type HealthCheck struct {
+	Name     string                          // название в метаданных "checks"
+	Check    func(ctx context.Context) error // nil - проверка прошла
+	Critical bool                            // ошибка делает сервис unhealthy, а не degraded
+}
+
+// HealthConfig настройки периодического отчета о состоянии
+type HealthConfig struct {
+	Interval     time.Duration // период отчетов (по умолчанию 1 минута)
+	CheckTimeout time.Duration // таймаут одной проверки (по умолчанию 5s)
+	Checks       []HealthCheck
+}
+
+// HealthReporter периодически отправляет события health_check с
+// метриками рантайма и результатами проверок
+type HealthReporter struct {
+	client  *Client
+	cfg     HealthConfig
+	started time.Time
+}
+
+// NewHealthReporter создает отчет о состоянии. Отсчет uptime начинается
+// с момента создания.
+func (c *Client) NewHealthReporter(cfg HealthConfig) *HealthReporter {
+	if cfg.Interval <= 0 {
+		cfg.Interval = defaultHealthInterval
+	}
+	if cfg.CheckTimeout <= 0 {
+		cfg.CheckTimeout = defaultHealthCheckTimeout
+	}
+	return &HealthReporter{client: c, cfg: cfg, started: time.Now()}
+}
+
+// Run отправляет отчет сразу и затем каждые Interval, пока не отменен ctx
+func (r *HealthReporter) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.cfg.Interval)
+	defer ticker.Stop()
+
+	r.Report(ctx)
+	for {
+		select {
+		case <-ticker.C:
+			r.Report(ctx)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Report выполняет проверки и отправляет один отчет
+func (r *HealthReporter) Report(ctx context.Context) error {
+	status, checks := r.runChecks(ctx)
+
+	metadata := runtimeMetadata()
+	metadata["uptime_seconds"] = time.Since(r.started).Seconds()
+	if len(checks) > 0 {
+		metadata["checks"] = checks
+	}
+
+	message := "service is " + string(status)
+	if failed := failedChecks(checks); len(failed) > 0 {
+		message += ": " + strings.Join(failed, ", ") + " failed"
+	}
+	return r.client.HealthContext(ctx, string(status), message, metadata)
+}
+
+// runChecks выполняет проверки параллельно и возвращает итоговое состояние
+func (r *HealthReporter) runChecks(ctx context.Context) (HealthStatus, map[string]interface{}) {
+	status := HealthHealthy
+	checks := make(map[string]interface{}, len(r.cfg.Checks))
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, check := range r.cfg.Checks {
+		wg.Add(1)
+		go func(check HealthCheck) {
+			defer wg.Done()
+			checkCtx, cancel := context.WithTimeout(ctx, r.cfg.CheckTimeout)
+			defer cancel()
+
+			start := time.Now()
+			err := check.Check(checkCtx)
+			result := map[string]interface{}{
+				"healthy":     err == nil,
+				"duration_ms": time.Since(start).Milliseconds(),
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				result["error"] = err.Error()
+				if check.Critical {
+					status = HealthUnhealthy
+				} else if status == HealthHealthy {
+					status = HealthDegraded
+				}
+			}
+			checks[check.Name] = result
+		}(check)
+	}
+	wg.Wait()
+	return status, checks
+}
+
+// failedChecks возвращает отсортированные названия непрошедших проверок
+func failedChecks(checks map[string]interface{}) []string {
+	var failed []string
+	for name, result := range checks {
+		if healthy, _ := result.(map[string]interface{})["healthy"].(bool); !healthy {
+			failed = append(failed, name)
+		}
+	}
+	sort.Strings(failed)
+	return failed
+}
+
+// runtimeMetadata собирает числовые метрики рантайма Go
+func runtimeMetadata() map[string]interface{} {
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+
+	metadata := map[string]interface{}{
+		"goroutines":        runtime.NumGoroutine(),
+		"heap_alloc_bytes":  mem.HeapAlloc,
+		"heap_inuse_bytes":  mem.HeapInuse,
+		"heap_objects":      mem.HeapObjects,
+		"sys_bytes":         mem.Sys,
+		"total_alloc_bytes": mem.TotalAlloc,
+		"gc_count":          mem.NumGC,
+		"gc_pause_total_ms": float64(mem.PauseTotalNs) / 1e6,
+		"gc_cpu_fraction":   mem.GCCPUFraction,
+	}
+	if mem.NumGC > 0 {
+		metadata["gc_pause_last_ms"] = float64(mem.PauseNs[(mem.NumGC+255)%256]) / 1e6
+	}
+	if user, system, ok := cpuTime(); ok {
+		metadata["cpu_user_seconds"] = user.Seconds()
+		metadata["cpu_system_seconds"] = system.Seconds()
+	}
+	return metadata
+}
@@ -0,0 +1,116 @@
+package logging
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestHealthReporter_ReportsRuntimeStats(t *testing.T) {
+	server, received := captureServer(t)
+	client := NewClient(server.URL, "test-service")
+	runtime.GC()
+
+	reporter := client.NewHealthReporter(HealthConfig{})
+	if err := reporter.Report(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	events := received()
+	if len(events) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(events))
+	}
+	m := events[0].Metadata
+	if events[0].Event != "health_check" || m["status"] != "healthy" {
+		t.Errorf("expected healthy health_check, got %s %v", events[0].Event, m["status"])
+	}
+	for _, key := range []string{"goroutines", "heap_alloc_bytes", "sys_bytes", "gc_count", "gc_pause_total_ms", "gc_pause_last_ms", "uptime_seconds"} {
+		if _, ok := m[key].(float64); !ok {
+			t.Errorf("expected numeric %s, got %v", key, m[key])
+		}
+	}
+	if _, _, ok := cpuTime(); ok {
+		if _, ok := m["cpu_user_seconds"].(float64); !ok {
+			t.Errorf("expected numeric cpu_user_seconds, got %v", m["cpu_user_seconds"])
+		}
+	}
+	if _, ok := m["checks"]; ok {
+		t.Errorf("expected no checks metadata without checks, got %v", m["checks"])
+	}
+}
+
+func TestHealthReporter_ChecksDetermineStatus(t *testing.T) {
+	ok := func(ctx context.Context) error { return nil }
+	fail := func(ctx context.Context) error { return errors.New("connection refused") }
+	slow := func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+
+	tests := []struct {
+		name   string
+		checks []HealthCheck
+		status string
+	}{
+		{"all passed", []HealthCheck{{Name: "db", Check: ok, Critical: true}, {Name: "redis", Check: ok}}, "healthy"},
+		{"optional failed", []HealthCheck{{Name: "db", Check: ok, Critical: true}, {Name: "redis", Check: fail}}, "degraded"},
+		{"critical timed out", []HealthCheck{{Name: "db", Check: slow, Critical: true}, {Name: "redis", Check: fail}}, "unhealthy"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, received := captureServer(t)
+			client := NewClient(server.URL, "test-service")
+
+			reporter := client.NewHealthReporter(HealthConfig{Checks: tt.checks, CheckTimeout: 20 * time.Millisecond})
+			reporter.Report(context.Background())
+
+			req := received()[0]
+			if req.Metadata["status"] != tt.status {
+				t.Errorf("expected status %s, got %v", tt.status, req.Metadata["status"])
+			}
+			checks, _ := req.Metadata["checks"].(map[string]interface{})
+			if len(checks) != len(tt.checks) {
+				t.Fatalf("expected %d check results, got %v", len(tt.checks), req.Metadata["checks"])
+			}
+			if tt.status != "healthy" {
+				redis := checks["redis"].(map[string]interface{})
+				if redis["healthy"] != false || redis["error"] != "connection refused" {
+					t.Errorf("unexpected redis result: %v", redis)
+				}
+			}
+		})
+	}
+}
+
+func TestHealthReporter_RunStopsOnCancel(t *testing.T) {
+	server, received := captureServer(t)
+	client := NewClient(server.URL, "test-service")
+	reporter := client.NewHealthReporter(HealthConfig{Interval: 10 * time.Millisecond})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		reporter.Run(ctx)
+		close(done)
+	}()
+
+	waitFor(t, func() bool { return len(received()) >= 3 })
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("expected Run to return after cancel")
+	}
+
+	// Отчет, отправленный в момент отмены, может дойти до сервера позже,
+	// поэтому учитываются только события, созданные после возврата Run
+	watermark := processSequence.Load()
+	time.Sleep(30 * time.Millisecond)
+	for _, req := range received() {
+		if req.Sequence > watermark {
+			t.Errorf("expected no reports after cancel, got %q", req.Message)
+		}
+	}
+}
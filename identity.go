@@ -0,0 +1,67 @@
+package logging
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"os"
+	"sync/atomic"
+	"time"
+)
+
+// IdempotencyKeyHeader заголовок с EventID события. Пакетные запросы его
+// не передают: дубликаты отбрасываются по event_id каждого события.
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// Данные процесса, общие для всех клиентов
+var (
+	// processSequence счетчик для LogRequest.Sequence
+	processSequence atomic.Uint64
+	// processInstanceID ID экземпляра по умолчанию, новый при каждом запуске
+	processInstanceID = newRandomID()
+	processHostname   = hostname()
+	processID         = os.Getpid()
+)
+
+func hostname() string {
+	name, err := os.Hostname()
+	if err != nil {
+		return ""
+	}
+	return name
+}
+
+// newRandomID возвращает случайный ID из 16 байт в hex
+func newRandomID() string {
+	var b [16]byte
+	rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
+
+// newEventID возвращает UUIDv7 (RFC 9562): 48 бит миллисекунд Unix времени
+// и 74 случайных бита. ID создаются в порядке времени, поэтому удобны
+// для индекса в PostgreSQL.
+func newEventID(t time.Time) string {
+	var b [16]byte
+	rand.Read(b[6:])
+	ms := uint64(t.UnixMilli())
+	b[0] = byte(ms >> 40)
+	b[1] = byte(ms >> 32)
+	b[2] = byte(ms >> 24)
+	b[3] = byte(ms >> 16)
+	b[4] = byte(ms >> 8)
+	b[5] = byte(ms)
+	b[6] = b[6]&0x0f | 0x70 // версия 7
+	b[8] = b[8]&0x3f | 0x80 // вариант RFC 9562
+
+	var buf [36]byte
+	hex.Encode(buf[0:8], b[0:4])
+	buf[8] = '-'
+	hex.Encode(buf[9:13], b[4:6])
+	buf[13] = '-'
+	hex.Encode(buf[14:18], b[6:8])
+	buf[18] = '-'
+	hex.Encode(buf[19:23], b[8:10])
+	buf[23] = '-'
+	hex.Encode(buf[24:], b[10:])
+	return string(buf[:])
+}
@@ -0,0 +1,181 @@
+package logging
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestLogRequest_ClientIdentity(t *testing.T) {
+	server, received := captureServer(t)
+
+	now := time.Date(2026, 3, 1, 12, 30, 0, 123000000, time.FixedZone("MSK", 3*60*60))
+	client := NewClient(server.URL, "test-service",
+		WithClock(func() time.Time { return now }),
+		WithEnvironment("staging"),
+	)
+	client.Info("test_event", "first", nil)
+	client.Named("search").Info("test_event", "second", nil)
+
+	events := received()
+	if len(events) != 2 {
+		t.Fatalf("expected 2 events, got %d", len(events))
+	}
+	req := events[0]
+	if req.Timestamp != "2026-03-01T09:30:00.123Z" {
+		t.Errorf("expected UTC RFC3339Nano timestamp, got %s", req.Timestamp)
+	}
+	if req.Environment != "staging" {
+		t.Errorf("expected environment staging, got %s", req.Environment)
+	}
+	if req.PID != os.Getpid() {
+		t.Errorf("expected pid %d, got %d", os.Getpid(), req.PID)
+	}
+	if host, _ := os.Hostname(); req.Hostname != host {
+		t.Errorf("expected hostname %s, got %s", host, req.Hostname)
+	}
+	if req.InstanceID != processInstanceID || len(req.InstanceID) != 32 {
+		t.Errorf("expected process instance ID, got %q", req.InstanceID)
+	}
+	if events[1].Sequence <= req.Sequence {
+		t.Errorf("expected increasing sequence, got %d then %d", req.Sequence, events[1].Sequence)
+	}
+}
+
+func TestLogRequest_SequenceSharedAcrossClients(t *testing.T) {
+	server, received := captureServer(t)
+	first := NewClient(server.URL, "first-service", WithInstanceID("replica-1"))
+	second := NewClient(server.URL, "second-service")
+
+	first.Info("test_event", "a", nil)
+	second.Info("test_event", "b", nil)
+	first.Info("test_event", "c", nil)
+
+	events := received()
+	for i := 1; i < len(events); i++ {
+		if events[i].Sequence != events[i-1].Sequence+1 {
+			t.Errorf("expected consecutive sequence numbers, got %d after %d", events[i].Sequence, events[i-1].Sequence)
+		}
+	}
+	if events[0].InstanceID != "replica-1" || events[1].InstanceID == "replica-1" {
+		t.Errorf("expected instance ID override per client, got %s and %s", events[0].InstanceID, events[1].InstanceID)
+	}
+}
+
+func TestLogRequest_TimestampSurvivesBatching(t *testing.T) {
+	recorder := &batchRecorder{}
+	server := httptest.NewServer(recorder.handler(t, http.StatusOK))
+	defer server.Close()
+
+	created := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	client := NewClient(server.URL, "test-service",
+		WithClock(func() time.Time { return created }),
+		WithAsync(AsyncConfig{}),
+		WithBatching(BatchConfig{FlushInterval: time.Hour}),
+	)
+	client.Info("test_event", "queued", nil)
+	client.Close(context.Background())
+
+	if len(recorder.batches) != 1 || len(recorder.batches[0]) != 1 {
+		t.Fatalf("expected 1 batch with 1 event, got %v", recorder.sizes())
+	}
+	if ts := recorder.batches[0][0].Timestamp; ts != "2026-01-01T00:00:00Z" {
+		t.Errorf("expected creation timestamp, got %s", ts)
+	}
+}
+
+func TestNewEventID_UUIDv7(t *testing.T) {
+	created := time.UnixMilli(0x0193_4f6a_2b10)
+	id := newEventID(created)
+
+	if len(id) != 36 || strings.Count(id, "-") != 4 {
+		t.Fatalf("expected UUID string, got %s", id)
+	}
+	if !strings.HasPrefix(id, "01934f6a-2b10-7") {
+		t.Errorf("expected timestamp prefix and version 7, got %s", id)
+	}
+	if v := id[19]; v != '8' && v != '9' && v != 'a' && v != 'b' {
+		t.Errorf("expected RFC 9562 variant, got %s", id)
+	}
+	if newEventID(created) == id {
+		t.Error("expected unique IDs for the same millisecond")
+	}
+}
+
+func TestEventID_StableAcrossRetriesAndSpoolReplay(t *testing.T) {
+	type attempt struct{ header, body string }
+	var available atomic.Bool
+	var mu sync.Mutex
+	var attempts []attempt
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req LogRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		mu.Lock()
+		attempts = append(attempts, attempt{r.Header.Get(IdempotencyKeyHeader), req.EventID})
+		mu.Unlock()
+		if !available.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	cfg := config{
+		Retry: fastRetry,
+		Spool: &SpoolConfig{Dir: t.TempDir(), ReplayInterval: time.Hour},
+	}
+	client := newClient(server.URL, "test-service", cfg)
+	client.Critical("stored after retries", nil)
+	client.Close(context.Background())
+
+	available.Store(true)
+	restarted := newClient(server.URL, "test-service", cfg)
+	defer restarted.Close(context.Background())
+	waitFor(t, func() bool { return restarted.Stats().Sent == 1 })
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(attempts) != fastRetry.MaxAttempts+1 {
+		t.Fatalf("expected %d attempts, got %d", fastRetry.MaxAttempts+1, len(attempts))
+	}
+	id := attempts[0].body
+	if id == "" {
+		t.Fatal("expected event_id in payload")
+	}
+	for i, a := range attempts {
+		if a.header != id || a.body != id {
+			t.Errorf("attempt %d: expected event ID %s, got header %s and body %s", i, id, a.header, a.body)
+		}
+	}
+}
+
+func TestEventID_BatchRequestHasNoIdempotencyKey(t *testing.T) {
+	var key atomic.Value
+	var events []LogRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key.Store(r.Header.Get(IdempotencyKeyHeader))
+		json.NewDecoder(r.Body).Decode(&events)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-service", WithBatching(BatchConfig{FlushInterval: time.Hour}))
+	client.Info("test_event", "first", nil)
+	client.Info("test_event", "second", nil)
+	client.Close(context.Background())
+
+	if k, _ := key.Load().(string); k != "" {
+		t.Errorf("expected no Idempotency-Key on batch request, got %s", k)
+	}
+	if len(events) != 2 || events[0].EventID == "" || events[0].EventID == events[1].EventID {
+		t.Errorf("expected distinct event IDs in batch, got %+v", events)
+	}
+}
@@ -0,0 +1,84 @@
+package logging
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Level уровень важности события. Уровни упорядочены: LevelDebug < LevelInfo <
+// LevelWarning < LevelError < LevelCritical. Нулевое значение означает, что
+// уровень не задан.
+type Level int8
+
+const (
+	LevelDebug    Level = iota + 1 // отладочная информация
+	LevelInfo                      // информационные события
+	LevelWarning                   // предупреждения
+	LevelError                     // ошибки
+	LevelCritical                  // критические события
+)
+
+// String возвращает название уровня в формате logging-service
+func (l Level) String() string {
+	switch l {
+	case LevelDebug:
+		return "DEBUG"
+	case LevelInfo:
+		return "INFO"
+	case LevelWarning:
+		return "WARNING"
+	case LevelError:
+		return "ERROR"
+	case LevelCritical:
+		return "CRITICAL"
+	default:
+		return "LEVEL(" + strconv.Itoa(int(l)) + ")"
+	}
+}
+
+// ParseLevel разбирает название уровня без учета регистра.
+// Помимо названий logging-service принимается сокращение "WARN".
+func ParseLevel(s string) (Level, error) {
+	switch strings.ToUpper(strings.TrimSpace(s)) {
+	case "DEBUG":
+		return LevelDebug, nil
+	case "INFO":
+		return LevelInfo, nil
+	case "WARNING", "WARN":
+		return LevelWarning, nil
+	case "ERROR":
+		return LevelError, nil
+	case "CRITICAL":
+		return LevelCritical, nil
+	default:
+		return 0, fmt.Errorf("unknown log level %q", s)
+	}
+}
+
+// MarshalText реализует encoding.TextMarshaler
+func (l Level) MarshalText() ([]byte, error) {
+	return []byte(l.String()), nil
+}
+
+// MarshalJSON кодирует уровень строкой, например "INFO"
+func (l Level) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(l.String())), nil
+}
+
+// UnmarshalText реализует encoding.TextUnmarshaler; используется и при
+// декодировании JSON строки
+func (l *Level) UnmarshalText(text []byte) error {
+	level, err := ParseLevel(string(text))
+	if err != nil {
+		return err
+	}
+	*l = level
+	return nil
+}
+
+// Enabled сообщает, будет ли отправлено событие уровня level.
+// Позволяет не собирать дорогие метаданные для отброшенных событий.
+func (c *Client) Enabled(level Level) bool {
+	return level >= c.minLevel.get()
+}
@@ -0,0 +1,199 @@
+package logging
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// levelVar минимальный уровень клиента, изменяемый во время работы.
+// Общий для клиента и всех производных логгеров.
+type levelVar struct {
+	current atomic.Int32
+
+	mu       sync.Mutex
+	base     Level       // уровень, к которому возвращаемся после TTL
+	revert   *time.Timer // таймер возврата временного уровня
+	revertAt time.Time
+	gen      uint64 // номер изменения, устаревший таймер не срабатывает
+}
+
+// errMissingLevel PUT запрос без уровня
+var errMissingLevel = errors.New("level is required")
+
+func newLevelVar(level Level) *levelVar {
+	level = effectiveLevel(level)
+	v := &levelVar{base: level}
+	v.current.Store(int32(level))
+	return v
+}
+
+func (v *levelVar) get() Level {
+	return Level(v.current.Load())
+}
+
+// effectiveLevel заменяет незаданный уровень на LevelDebug: без
+// минимального уровня отправляются все события, а "DEBUG" в отличие
+// от нулевого значения принимает ParseLevel
+func effectiveLevel(level Level) Level {
+	if level < LevelDebug {
+		return LevelDebug
+	}
+	return level
+}
+
+// Level возвращает текущий минимальный уровень клиента
+func (c *Client) Level() Level {
+	return c.minLevel.get()
+}
+
+// SetLevel меняет минимальный уровень клиента и всех производных логгеров.
+// Изменение логируется событием log_level_changed.
+func (c *Client) SetLevel(level Level) {
+	c.setLevel(level, 0)
+}
+
+// SetLevelFor временно меняет минимальный уровень. По истечении ttl
+// восстанавливается уровень, заданный последним вызовом SetLevel.
+func (c *Client) SetLevelFor(level Level, ttl time.Duration) {
+	c.setLevel(level, ttl)
+}
+
+// setLevel применяет уровень и, при ttl > 0, планирует возврат
+func (c *Client) setLevel(level Level, ttl time.Duration) {
+	level = effectiveLevel(level)
+	v := c.minLevel
+	v.mu.Lock()
+	previous := v.get()
+	v.gen++
+	gen := v.gen
+	if v.revert != nil {
+		v.revert.Stop()
+		v.revert = nil
+		v.revertAt = time.Time{}
+	}
+	if ttl > 0 {
+		v.revertAt = time.Now().Add(ttl)
+		v.revert = time.AfterFunc(ttl, func() { c.revertLevel(gen) })
+	} else {
+		v.base = level
+	}
+	v.current.Store(int32(level))
+	v.mu.Unlock()
+
+	metadata := map[string]interface{}{
+		"previous_level": previous.String(),
+		"new_level":      level.String(),
+	}
+	if ttl > 0 {
+		metadata["ttl_seconds"] = ttl.Seconds()
+	}
+	c.emit(context.Background(), LevelInfo, "log_level_changed", "log level changed to "+level.String(), metadata)
+}
+
+// revertLevel возвращает базовый уровень после истечения TTL
+func (c *Client) revertLevel(gen uint64) {
+	v := c.minLevel
+	v.mu.Lock()
+	if v.gen != gen {
+		// Уровень уже изменен повторно
+		v.mu.Unlock()
+		return
+	}
+	previous := v.get()
+	level := v.base
+	v.revert = nil
+	v.revertAt = time.Time{}
+	v.current.Store(int32(level))
+	v.mu.Unlock()
+
+	metadata := map[string]interface{}{
+		"previous_level": previous.String(),
+		"new_level":      level.String(),
+		"reason":         "ttl_expired",
+	}
+	c.emit(context.Background(), LevelInfo, "log_level_changed", "log level reverted to "+level.String(), metadata)
+}
+
+// levelState состояние уровня для ответа LevelHandler
+type levelState struct {
+	Level    Level      `json:"level"`
+	RevertAt *time.Time `json:"revert_at,omitempty"`
+}
+
+// levelChange тело PUT запроса к LevelHandler
+type levelChange struct {
+	Level Level  `json:"level"`
+	TTL   string `json:"ttl,omitempty"`
+}
+
+// LevelHandler возвращает http.Handler для управления уровнем на admin порту:
+//
+//	GET  - текущий уровень: {"level":"INFO"}
+//	PUT  - новый уровень: {"level":"DEBUG","ttl":"15m"}, ttl необязателен
+//
+// Уровень и ttl можно передать и параметрами запроса: PUT ?level=DEBUG&ttl=15m.
+func (c *Client) LevelHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+		case http.MethodPut:
+			change, err := parseLevelChange(r)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			var ttl time.Duration
+			if change.TTL != "" {
+				ttl, err = time.ParseDuration(change.TTL)
+				if err != nil || ttl < 0 {
+					http.Error(w, "invalid ttl "+change.TTL, http.StatusBadRequest)
+					return
+				}
+			}
+			c.setLevel(change.Level, ttl)
+		default:
+			w.Header().Set("Allow", "GET, PUT")
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(c.levelState())
+	})
+}
+
+// parseLevelChange читает изменение уровня из параметров или JSON тела
+func parseLevelChange(r *http.Request) (levelChange, error) {
+	var change levelChange
+	query := r.URL.Query()
+	if query.Has("level") {
+		change.TTL = query.Get("ttl")
+		return change, change.Level.UnmarshalText([]byte(query.Get("level")))
+	}
+	if err := json.NewDecoder(io.LimitReader(r.Body, 4<<10)).Decode(&change); err != nil {
+		return change, err
+	}
+	if change.Level == 0 {
+		return change, errMissingLevel
+	}
+	return change, nil
+}
+
+// levelState возвращает текущий уровень и время возврата временного уровня
+func (c *Client) levelState() levelState {
+	v := c.minLevel
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	state := levelState{Level: v.get()}
+	if !v.revertAt.IsZero() {
+		revertAt := v.revertAt
+		state.RevertAt = &revertAt
+	}
+	return state
+}
@@ -0,0 +1,157 @@
+package logging
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSetLevel_AppliesToDerivedLoggers(t *testing.T) {
+	server, received := captureServer(t)
+
+	client := NewClient(server.URL, "test-service", WithMinLevel(LevelWarning))
+	logger := client.Named("search")
+	client.SetLevel(LevelDebug)
+
+	if client.Level() != LevelDebug {
+		t.Errorf("expected level DEBUG, got %s", client.Level())
+	}
+	if !logger.Enabled(LevelDebug) {
+		t.Error("expected derived logger to follow the new level")
+	}
+
+	events := received()
+	if len(events) != 1 {
+		t.Fatalf("expected 1 level change event, got %d", len(events))
+	}
+	if events[0].Event != "log_level_changed" || events[0].Level != "INFO" {
+		t.Errorf("expected INFO log_level_changed, got %s %s", events[0].Level, events[0].Event)
+	}
+	if events[0].Metadata["previous_level"] != "WARNING" || events[0].Metadata["new_level"] != "DEBUG" {
+		t.Errorf("unexpected metadata: %v", events[0].Metadata)
+	}
+}
+
+func TestSetLevelFor_RevertsAfterTTL(t *testing.T) {
+	server, received := captureServer(t)
+
+	client := NewClient(server.URL, "test-service", WithMinLevel(LevelError))
+	client.SetLevelFor(LevelDebug, 20*time.Millisecond)
+	if client.Level() != LevelDebug {
+		t.Fatalf("expected level DEBUG, got %s", client.Level())
+	}
+
+	waitFor(t, func() bool { return client.Level() == LevelError })
+	waitFor(t, func() bool { return len(received()) == 2 })
+
+	revert := received()[1]
+	if revert.Metadata["reason"] != "ttl_expired" || revert.Metadata["new_level"] != "ERROR" {
+		t.Errorf("unexpected revert metadata: %v", revert.Metadata)
+	}
+}
+
+func TestSetLevel_CancelsPendingRevert(t *testing.T) {
+	server, _ := captureServer(t)
+
+	client := NewClient(server.URL, "test-service")
+	client.SetLevelFor(LevelDebug, 20*time.Millisecond)
+	client.SetLevel(LevelWarning)
+
+	time.Sleep(50 * time.Millisecond)
+	if client.Level() != LevelWarning {
+		t.Errorf("expected level WARNING, got %s", client.Level())
+	}
+}
+
+func TestLevelHandler_GetAndPut(t *testing.T) {
+	server, _ := captureServer(t)
+	client := NewClient(server.URL, "test-service", WithMinLevel(LevelInfo))
+	handler := client.LevelHandler()
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/level", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rec.Code)
+	}
+	if body := strings.TrimSpace(rec.Body.String()); body != `{"level":"INFO"}` {
+		t.Errorf("expected INFO level, got %s", body)
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/level", strings.NewReader(`{"level":"debug","ttl":"1m"}`)))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var state levelState
+	json.NewDecoder(rec.Body).Decode(&state)
+	if state.Level != LevelDebug || state.RevertAt == nil {
+		t.Errorf("expected DEBUG with revert_at, got %+v", state)
+	}
+	if client.Level() != LevelDebug {
+		t.Errorf("expected level DEBUG, got %s", client.Level())
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/level?level=error", nil))
+	if rec.Code != http.StatusOK || client.Level() != LevelError {
+		t.Errorf("expected level ERROR via query, got %d %s", rec.Code, client.Level())
+	}
+}
+
+func TestLevelHandler_RejectsInvalidRequests(t *testing.T) {
+	server, received := captureServer(t)
+	client := NewClient(server.URL, "test-service")
+	handler := client.LevelHandler()
+
+	tests := []struct {
+		method string
+		target string
+		body   string
+		status int
+	}{
+		{http.MethodPut, "/level", `{"level":"verbose"}`, http.StatusBadRequest},
+		{http.MethodPut, "/level", `{}`, http.StatusBadRequest},
+		{http.MethodPut, "/level", `{"level":"DEBUG","ttl":"soon"}`, http.StatusBadRequest},
+		{http.MethodPost, "/level", `{"level":"DEBUG"}`, http.StatusMethodNotAllowed},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body)))
+		if rec.Code != tt.status {
+			t.Errorf("%s %s: expected status %d, got %d", tt.method, tt.body, tt.status, rec.Code)
+		}
+	}
+	if client.Level() != LevelDebug {
+		t.Errorf("expected level to stay DEBUG, got %s", client.Level())
+	}
+	if len(received()) != 0 {
+		t.Errorf("expected no events, got %d", len(received()))
+	}
+}
+
+func TestLevelHandler_DefaultLevelRoundTrip(t *testing.T) {
+	server, received := captureServer(t)
+	client := NewClient(server.URL, "test-service")
+	handler := client.LevelHandler()
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/level", nil))
+	body := strings.TrimSpace(rec.Body.String())
+	if body != `{"level":"DEBUG"}` {
+		t.Fatalf("expected effective level DEBUG, got %s", body)
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/level", strings.NewReader(body)))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected GET response to be accepted by PUT, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	events := received()
+	if len(events) != 1 || events[0].Metadata["previous_level"] != "DEBUG" {
+		t.Errorf("expected previous_level DEBUG, got %v", events)
+	}
+}
@@ -0,0 +1,124 @@
+package logging
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestLevel_String(t *testing.T) {
+	tests := map[Level]string{
+		LevelDebug:    "DEBUG",
+		LevelInfo:     "INFO",
+		LevelWarning:  "WARNING",
+		LevelError:    "ERROR",
+		LevelCritical: "CRITICAL",
+		Level(42):     "LEVEL(42)",
+	}
+	for level, expected := range tests {
+		if got := level.String(); got != expected {
+			t.Errorf("expected %s, got %s", expected, got)
+		}
+	}
+}
+
+func TestLevel_Ordering(t *testing.T) {
+	ordered := []Level{LevelDebug, LevelInfo, LevelWarning, LevelError, LevelCritical}
+	for i := 1; i < len(ordered); i++ {
+		if ordered[i-1] >= ordered[i] {
+			t.Errorf("expected %s < %s", ordered[i-1], ordered[i])
+		}
+	}
+}
+
+func TestParseLevel(t *testing.T) {
+	tests := map[string]Level{
+		"DEBUG":     LevelDebug,
+		"info":      LevelInfo,
+		" Warning ": LevelWarning,
+		"warn":      LevelWarning,
+		"error":     LevelError,
+		"CRITICAL":  LevelCritical,
+	}
+	for input, expected := range tests {
+		level, err := ParseLevel(input)
+		if err != nil {
+			t.Errorf("ParseLevel(%q): unexpected error: %v", input, err)
+			continue
+		}
+		if level != expected {
+			t.Errorf("ParseLevel(%q): expected %s, got %s", input, expected, level)
+		}
+	}
+
+	if _, err := ParseLevel("INFOO"); err == nil {
+		t.Error("expected error for unknown level INFOO")
+	}
+}
+
+func TestLevel_JSON(t *testing.T) {
+	data, err := json.Marshal(map[string]Level{"level": LevelWarning})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(data) != `{"level":"WARNING"}` {
+		t.Errorf("unexpected JSON: %s", data)
+	}
+
+	var decoded struct {
+		Level Level `json:"level"`
+	}
+	if err := json.Unmarshal([]byte(`{"level":"critical"}`), &decoded); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if decoded.Level != LevelCritical {
+		t.Errorf("expected CRITICAL, got %s", decoded.Level)
+	}
+
+	if err := json.Unmarshal([]byte(`{"level":"INFOO"}`), &decoded); err == nil {
+		t.Error("expected error for unknown level")
+	}
+}
+
+func TestClient_MinLevelSkipsHTTP(t *testing.T) {
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-service", WithMinLevel(LevelError))
+
+	client.Debug("debug", nil)
+	client.HTTPRequest("GET", "/search", 200, time.Millisecond, nil)
+	client.ServiceCommunication("gateway-service", "send_update", true, time.Millisecond, nil)
+	if hits.Load() != 0 {
+		t.Errorf("expected filtered events not to reach server, got %d requests", hits.Load())
+	}
+
+	client.ServiceCommunication("gateway-service", "send_update", false, time.Millisecond, nil)
+	if hits.Load() != 1 {
+		t.Errorf("expected failed communication (ERROR) to be sent, got %d requests", hits.Load())
+	}
+
+	if client.Enabled(LevelWarning) || !client.Enabled(LevelCritical) {
+		t.Error("unexpected Enabled result")
+	}
+}
+
+func TestClient_FilteredEventsDoNotAllocate(t *testing.T) {
+	client := NewClient("http://localhost:8080", "test-service", WithMinLevel(LevelError))
+
+	allocs := testing.AllocsPerRun(100, func() {
+		client.Debug("debug", nil)
+		client.HTTPRequest("GET", "/search", 200, time.Millisecond, nil)
+		client.Health("healthy", "ok", nil)
+	})
+	if allocs != 0 {
+		t.Errorf("expected no allocations for filtered events, got %v", allocs)
+	}
+}
@@ -0,0 +1,138 @@
+package logging
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/signal"
+	"runtime/debug"
+	"syscall"
+	"time"
+)
+
+const defaultShutdownTimeout = 10 * time.Second
+
+// LifecycleConfig настройки Run
+type LifecycleConfig struct {
+	// Version версия в событии service_start (по умолчанию версия модуля
+	// или VCS ревизия из debug.ReadBuildInfo)
+	Version string
+	// Signals сигналы остановки (по умолчанию SIGINT и SIGTERM)
+	Signals []os.Signal
+	// ShutdownTimeout время на отправку оставшихся событий (по умолчанию 10s)
+	ShutdownTimeout time.Duration
+}
+
+// Run оборачивает жизненный цикл сервиса: логирует service_start с данными
+// сборки, выполняет run до его завершения или сигнала остановки, логирует
+// service_stop с uptime и причиной и закрывает клиент, дожидаясь отправки
+// событий не дольше ShutdownTimeout. При сигнале ctx, переданный в run,
+// отменяется, а повторный сигнал завершает процесс без ожидания.
+// Возвращает ошибку run.
+//
+//	err := logger.Run(context.Background(), logging.LifecycleConfig{}, func(ctx context.Context) error {
+//		return server.Serve(ctx)
+//	})
+func (c *Client) Run(ctx context.Context, cfg LifecycleConfig, run func(ctx context.Context) error) error {
+	if cfg.Signals == nil {
+		cfg.Signals = []os.Signal{os.Interrupt, syscall.SIGTERM}
+	}
+	if cfg.ShutdownTimeout <= 0 {
+		cfg.ShutdownTimeout = defaultShutdownTimeout
+	}
+
+	started := time.Now()
+	info := buildMetadata()
+	version := cfg.Version
+	if version == "" {
+		version = buildVersion(info)
+	}
+	c.With(info).ServiceStartContext(ctx, version, "service started")
+
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, cfg.Signals...)
+	defer signal.Stop(signals)
+
+	received := make(chan os.Signal, 1)
+	go func() {
+		select {
+		case sig := <-signals:
+			// Повторный сигнал во время остановки завершает процесс
+			// обработчиком по умолчанию
+			signal.Stop(signals)
+			received <- sig
+			cancel()
+		case <-runCtx.Done():
+		}
+	}()
+
+	err := run(runCtx)
+
+	var reason string
+	select {
+	case sig := <-received:
+		reason = "received signal " + sig.String()
+	default:
+		switch {
+		case err != nil:
+			reason = "error: " + err.Error()
+		case ctx.Err() != nil:
+			reason = "context done: " + ctx.Err().Error()
+		default:
+			reason = "completed"
+		}
+	}
+
+	shutdownCtx, shutdownCancel := context.WithTimeout(context.WithoutCancel(ctx), cfg.ShutdownTimeout)
+	defer shutdownCancel()
+	c.With(map[string]interface{}{"reason": reason}).
+		ServiceStopContext(shutdownCtx, time.Since(started), "service stopped: "+reason)
+	if closeErr := c.Close(shutdownCtx); closeErr != nil {
+		return errors.Join(err, closeErr)
+	}
+	return err
+}
+
+// buildMetadata возвращает данные сборки из debug.ReadBuildInfo
+func buildMetadata() map[string]interface{} {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return nil
+	}
+	metadata := map[string]interface{}{
+		"go_version":     info.GoVersion,
+		"module_path":    info.Main.Path,
+		"module_version": info.Main.Version,
+	}
+	for _, setting := range info.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			metadata["vcs_revision"] = setting.Value
+		case "vcs.time":
+			metadata["vcs_time"] = setting.Value
+		case "vcs.modified":
+			metadata["vcs_modified"] = setting.Value == "true"
+		}
+	}
+	return metadata
+}
+
+// buildVersion выбирает версию для service_start: версию модуля, а для
+// сборок из рабочей копии - короткую VCS ревизию
+func buildVersion(info map[string]interface{}) string {
+	if version, _ := info["module_version"].(string); version != "" && version != "(devel)" {
+		return version
+	}
+	if revision, _ := info["vcs_revision"].(string); revision != "" {
+		if len(revision) > 12 {
+			revision = revision[:12]
+		}
+		if modified, _ := info["vcs_modified"].(bool); modified {
+			revision += "-dirty"
+		}
+		return revision
+	}
+	return "unknown"
+}
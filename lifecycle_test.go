@@ -0,0 +1,83 @@
+package logging
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestRun_LogsStartAndStop(t *testing.T) {
+	server, received := captureServer(t)
+	client := NewClient(server.URL, "test-service")
+
+	runErr := errors.New("listener closed")
+	err := client.Run(context.Background(), LifecycleConfig{Version: "v1.2.3"}, func(ctx context.Context) error {
+		return runErr
+	})
+	if !errors.Is(err, runErr) {
+		t.Errorf("expected run error, got %v", err)
+	}
+
+	events := received()
+	if len(events) != 2 {
+		t.Fatalf("expected 2 events, got %d", len(events))
+	}
+	start, stop := events[0], events[1]
+	if start.Event != "service_start" || start.Metadata["version"] != "v1.2.3" {
+		t.Errorf("unexpected start event: %s %v", start.Event, start.Metadata)
+	}
+	if _, ok := start.Metadata["go_version"].(string); !ok {
+		t.Errorf("expected go_version from build info, got %v", start.Metadata)
+	}
+	if stop.Event != "service_stop" || stop.Metadata["reason"] != "error: listener closed" {
+		t.Errorf("unexpected stop event: %s %v", stop.Event, stop.Metadata)
+	}
+	if _, ok := stop.Metadata["uptime_seconds"].(float64); !ok {
+		t.Errorf("expected uptime_seconds, got %v", stop.Metadata["uptime_seconds"])
+	}
+}
+
+func TestRun_StopsOnSignal(t *testing.T) {
+	server, received := captureServer(t)
+	client := NewClient(server.URL, "test-service", WithAsync(AsyncConfig{}))
+
+	err := client.Run(context.Background(), LifecycleConfig{Signals: []os.Signal{os.Interrupt}}, func(ctx context.Context) error {
+		process, _ := os.FindProcess(os.Getpid())
+		if err := process.Signal(os.Interrupt); err != nil {
+			t.Skipf("sending signals is not supported: %v", err)
+		}
+		<-ctx.Done()
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Run закрывает клиент, поэтому события асинхронного клиента уже доставлены
+	events := received()
+	if len(events) != 2 {
+		t.Fatalf("expected 2 events after Run, got %d", len(events))
+	}
+	if reason, _ := events[1].Metadata["reason"].(string); !strings.HasPrefix(reason, "received signal") {
+		t.Errorf("expected signal reason, got %q", reason)
+	}
+}
+
+func TestBuildVersion(t *testing.T) {
+	tests := []struct {
+		info map[string]interface{}
+		want string
+	}{
+		{map[string]interface{}{"module_version": "v1.4.0"}, "v1.4.0"},
+		{map[string]interface{}{"module_version": "(devel)", "vcs_revision": "0123456789abcdef", "vcs_modified": true}, "0123456789ab-dirty"},
+		{map[string]interface{}{"module_version": "(devel)"}, "unknown"},
+		{nil, "unknown"},
+	}
+	for _, tt := range tests {
+		if got := buildVersion(tt.info); got != tt.want {
+			t.Errorf("expected %s for %v, got %s", tt.want, tt.info, got)
+		}
+	}
+}
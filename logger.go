@@ -0,0 +1,273 @@
+package logging
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Logger типизированный API логирования. Реализуется *Client, NopLogger и
+// MultiLogger, поэтому сервисы могут зависеть от интерфейса, а в тестах
+// подставлять NopLogger или свою реализацию.
+type Logger interface {
+	ServiceStart(version, message string) error
+	ServiceStartContext(ctx context.Context, version, message string) error
+	ServiceStop(uptime time.Duration, message string) error
+	ServiceStopContext(ctx context.Context, uptime time.Duration, message string) error
+	Health(status, message string, metadata map[string]interface{}) error
+	HealthContext(ctx context.Context, status, message string, metadata map[string]interface{}) error
+	Error(err error, message string, metadata map[string]interface{}) error
+	ErrorContext(ctx context.Context, err error, message string, metadata map[string]interface{}) error
+	Warning(message string, metadata map[string]interface{}) error
+	WarningContext(ctx context.Context, message string, metadata map[string]interface{}) error
+	Info(event, message string, metadata map[string]interface{}) error
+	InfoContext(ctx context.Context, event, message string, metadata map[string]interface{}) error
+	Critical(message string, metadata map[string]interface{}) error
+	CriticalContext(ctx context.Context, message string, metadata map[string]interface{}) error
+	Debug(message string, metadata map[string]interface{}) error
+	DebugContext(ctx context.Context, message string, metadata map[string]interface{}) error
+	HTTPRequest(method, path string, statusCode int, duration time.Duration, metadata map[string]interface{}) error
+	HTTPRequestContext(ctx context.Context, method, path string, statusCode int, duration time.Duration, metadata map[string]interface{}) error
+	ExternalAPI(apiName, endpoint string, statusCode int, duration time.Duration, metadata map[string]interface{}) error
+	ExternalAPIContext(ctx context.Context, apiName, endpoint string, statusCode int, duration time.Duration, metadata map[string]interface{}) error
+	ServiceCommunication(targetService, operation string, success bool, duration time.Duration, metadata map[string]interface{}) error
+	ServiceCommunicationContext(ctx context.Context, targetService, operation string, success bool, duration time.Duration, metadata map[string]interface{}) error
+}
+
+// Проверка, что *Client реализует Logger
+var _ Logger = (*Client)(nil)
+
+// NopLogger реализация Logger, которая ничего не делает
+type NopLogger struct{}
+
+var _ Logger = NopLogger{}
+
+// ServiceStart ничего не делает
+func (NopLogger) ServiceStart(_, _ string) error { return nil }
+
+// ServiceStartContext ничего не делает
+func (NopLogger) ServiceStartContext(_ context.Context, _, _ string) error { return nil }
+
+// ServiceStop ничего не делает
+func (NopLogger) ServiceStop(_ time.Duration, _ string) error { return nil }
+
+// ServiceStopContext ничего не делает
+func (NopLogger) ServiceStopContext(_ context.Context, _ time.Duration, _ string) error { return nil }
+
+// Health ничего не делает
+func (NopLogger) Health(_, _ string, _ map[string]interface{}) error { return nil }
+
+// HealthContext ничего не делает
+func (NopLogger) HealthContext(_ context.Context, _, _ string, _ map[string]interface{}) error {
+	return nil
+}
+
+// Error ничего не делает
+func (NopLogger) Error(_ error, _ string, _ map[string]interface{}) error { return nil }
+
+// ErrorContext ничего не делает
+func (NopLogger) ErrorContext(_ context.Context, _ error, _ string, _ map[string]interface{}) error {
+	return nil
+}
+
+// Warning ничего не делает
+func (NopLogger) Warning(_ string, _ map[string]interface{}) error { return nil }
+
+// WarningContext ничего не делает
+func (NopLogger) WarningContext(_ context.Context, _ string, _ map[string]interface{}) error {
+	return nil
+}
+
+// Info ничего не делает
+func (NopLogger) Info(_, _ string, _ map[string]interface{}) error { return nil }
+
+// InfoContext ничего не делает
+func (NopLogger) InfoContext(_ context.Context, _, _ string, _ map[string]interface{}) error {
+	return nil
+}
+
+// Critical ничего не делает
+func (NopLogger) Critical(_ string, _ map[string]interface{}) error { return nil }
+
+// CriticalContext ничего не делает
+func (NopLogger) CriticalContext(_ context.Context, _ string, _ map[string]interface{}) error {
+	return nil
+}
+
+// Debug ничего не делает
+func (NopLogger) Debug(_ string, _ map[string]interface{}) error { return nil }
+
+// DebugContext ничего не делает
+func (NopLogger) DebugContext(_ context.Context, _ string, _ map[string]interface{}) error {
+	return nil
+}
+
+// HTTPRequest ничего не делает
+func (NopLogger) HTTPRequest(_, _ string, _ int, _ time.Duration, _ map[string]interface{}) error {
+	return nil
+}
+
+// HTTPRequestContext ничего не делает
+func (NopLogger) HTTPRequestContext(_ context.Context, _, _ string, _ int, _ time.Duration, _ map[string]interface{}) error {
+	return nil
+}
+
+// ExternalAPI ничего не делает
+func (NopLogger) ExternalAPI(_, _ string, _ int, _ time.Duration, _ map[string]interface{}) error {
+	return nil
+}
+
+// ExternalAPIContext ничего не делает
+func (NopLogger) ExternalAPIContext(_ context.Context, _, _ string, _ int, _ time.Duration, _ map[string]interface{}) error {
+	return nil
+}
+
+// ServiceCommunication ничего не делает
+func (NopLogger) ServiceCommunication(_, _ string, _ bool, _ time.Duration, _ map[string]interface{}) error {
+	return nil
+}
+
+// ServiceCommunicationContext ничего не делает
+func (NopLogger) ServiceCommunicationContext(_ context.Context, _, _ string, _ bool, _ time.Duration, _ map[string]interface{}) error {
+	return nil
+}
+
+// MultiLogger рассылает каждое событие во все вложенные логгеры.
+// Ошибки всех логгеров объединяются через errors.Join.
+type MultiLogger struct {
+	loggers []Logger
+}
+
+var _ Logger = (*MultiLogger)(nil)
+
+// NewMultiLogger создает логгер, рассылающий события в loggers
+func NewMultiLogger(loggers ...Logger) *MultiLogger {
+	return &MultiLogger{loggers: append([]Logger(nil), loggers...)}
+}
+
+// each вызывает fn для каждого логгера и объединяет ошибки
+func (m *MultiLogger) each(fn func(Logger) error) error {
+	var errs []error
+	for _, l := range m.loggers {
+		if err := fn(l); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// ServiceStart рассылает событие во все логгеры
+func (m *MultiLogger) ServiceStart(version, message string) error {
+	return m.each(func(l Logger) error { return l.ServiceStart(version, message) })
+}
+
+// ServiceStartContext рассылает событие во все логгеры
+func (m *MultiLogger) ServiceStartContext(ctx context.Context, version, message string) error {
+	return m.each(func(l Logger) error { return l.ServiceStartContext(ctx, version, message) })
+}
+
+// ServiceStop рассылает событие во все логгеры
+func (m *MultiLogger) ServiceStop(uptime time.Duration, message string) error {
+	return m.each(func(l Logger) error { return l.ServiceStop(uptime, message) })
+}
+
+// ServiceStopContext рассылает событие во все логгеры
+func (m *MultiLogger) ServiceStopContext(ctx context.Context, uptime time.Duration, message string) error {
+	return m.each(func(l Logger) error { return l.ServiceStopContext(ctx, uptime, message) })
+}
+
+// Health рассылает событие во все логгеры
+func (m *MultiLogger) Health(status, message string, metadata map[string]interface{}) error {
+	return m.each(func(l Logger) error { return l.Health(status, message, metadata) })
+}
+
+// HealthContext рассылает событие во все логгеры
+func (m *MultiLogger) HealthContext(ctx context.Context, status, message string, metadata map[string]interface{}) error {
+	return m.each(func(l Logger) error { return l.HealthContext(ctx, status, message, metadata) })
+}
+
+// Error рассылает событие во все логгеры
+func (m *MultiLogger) Error(err error, message string, metadata map[string]interface{}) error {
+	return m.each(func(l Logger) error { return l.Error(err, message, metadata) })
+}
+
+// ErrorContext рассылает событие во все логгеры
+func (m *MultiLogger) ErrorContext(ctx context.Context, err error, message string, metadata map[string]interface{}) error {
+	return m.each(func(l Logger) error { return l.ErrorContext(ctx, err, message, metadata) })
+}
+
+// Warning рассылает событие во все логгеры
+func (m *MultiLogger) Warning(message string, metadata map[string]interface{}) error {
+	return m.each(func(l Logger) error { return l.Warning(message, metadata) })
+}
+
+// WarningContext рассылает событие во все логгеры
+func (m *MultiLogger) WarningContext(ctx context.Context, message string, metadata map[string]interface{}) error {
+	return m.each(func(l Logger) error { return l.WarningContext(ctx, message, metadata) })
+}
+
+// Info рассылает событие во все логгеры
+func (m *MultiLogger) Info(event, message string, metadata map[string]interface{}) error {
+	return m.each(func(l Logger) error { return l.Info(event, message, metadata) })
+}
+
+// InfoContext рассылает событие во все логгеры
+func (m *MultiLogger) InfoContext(ctx context.Context, event, message string, metadata map[string]interface{}) error {
+	return m.each(func(l Logger) error { return l.InfoContext(ctx, event, message, metadata) })
+}
+
+// Critical рассылает событие во все логгеры
+func (m *MultiLogger) Critical(message string, metadata map[string]interface{}) error {
+	return m.each(func(l Logger) error { return l.Critical(message, metadata) })
+}
+
+// CriticalContext рассылает событие во все логгеры
+func (m *MultiLogger) CriticalContext(ctx context.Context, message string, metadata map[string]interface{}) error {
+	return m.each(func(l Logger) error { return l.CriticalContext(ctx, message, metadata) })
+}
+
+// Debug рассылает событие во все логгеры
+func (m *MultiLogger) Debug(message string, metadata map[string]interface{}) error {
+	return m.each(func(l Logger) error { return l.Debug(message, metadata) })
+}
+
+// DebugContext рассылает событие во все логгеры
+func (m *MultiLogger) DebugContext(ctx context.Context, message string, metadata map[string]interface{}) error {
+	return m.each(func(l Logger) error { return l.DebugContext(ctx, message, metadata) })
+}
+
+// HTTPRequest рассылает событие во все логгеры
+func (m *MultiLogger) HTTPRequest(method, path string, statusCode int, duration time.Duration, metadata map[string]interface{}) error {
+	return m.each(func(l Logger) error { return l.HTTPRequest(method, path, statusCode, duration, metadata) })
+}
+
+// HTTPRequestContext рассылает событие во все логгеры
+func (m *MultiLogger) HTTPRequestContext(ctx context.Context, method, path string, statusCode int, duration time.Duration, metadata map[string]interface{}) error {
+	return m.each(func(l Logger) error { return l.HTTPRequestContext(ctx, method, path, statusCode, duration, metadata) })
+}
+
+// ExternalAPI рассылает событие во все логгеры
+func (m *MultiLogger) ExternalAPI(apiName, endpoint string, statusCode int, duration time.Duration, metadata map[string]interface{}) error {
+	return m.each(func(l Logger) error { return l.ExternalAPI(apiName, endpoint, statusCode, duration, metadata) })
+}
+
+// ExternalAPIContext рассылает событие во все логгеры
+func (m *MultiLogger) ExternalAPIContext(ctx context.Context, apiName, endpoint string, statusCode int, duration time.Duration, metadata map[string]interface{}) error {
+	return m.each(func(l Logger) error {
+		return l.ExternalAPIContext(ctx, apiName, endpoint, statusCode, duration, metadata)
+	})
+}
+
+// ServiceCommunication рассылает событие во все логгеры
+func (m *MultiLogger) ServiceCommunication(targetService, operation string, success bool, duration time.Duration, metadata map[string]interface{}) error {
+	return m.each(func(l Logger) error {
+		return l.ServiceCommunication(targetService, operation, success, duration, metadata)
+	})
+}
+
+// ServiceCommunicationContext рассылает событие во все логгеры
+func (m *MultiLogger) ServiceCommunicationContext(ctx context.Context, targetService, operation string, success bool, duration time.Duration, metadata map[string]interface{}) error {
+	return m.each(func(l Logger) error {
+		return l.ServiceCommunicationContext(ctx, targetService, operation, success, duration, metadata)
+	})
+}
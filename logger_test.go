@@ -0,0 +1,98 @@
+package logging
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// recordingLogger запоминает вызовы для проверки MultiLogger
+type recordingLogger struct {
+	NopLogger
+	calls []string
+	err   error
+}
+
+func (r *recordingLogger) Info(event, message string, metadata map[string]interface{}) error {
+	r.calls = append(r.calls, "Info:"+event)
+	return r.err
+}
+
+func (r *recordingLogger) ErrorContext(ctx context.Context, err error, message string, metadata map[string]interface{}) error {
+	r.calls = append(r.calls, "ErrorContext:"+err.Error())
+	return r.err
+}
+
+func (r *recordingLogger) HTTPRequest(method, path string, statusCode int, duration time.Duration, metadata map[string]interface{}) error {
+	r.calls = append(r.calls, "HTTPRequest:"+method+" "+path)
+	return r.err
+}
+
+func TestNopLogger(t *testing.T) {
+	var logger Logger = NopLogger{}
+
+	if err := logger.Info("test_event", "message", nil); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := logger.Error(errors.New("boom"), "message", nil); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := logger.ServiceCommunicationContext(context.Background(), "gateway-service", "send", false, time.Second, nil); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestMultiLogger_FansOut(t *testing.T) {
+	first := &recordingLogger{}
+	second := &recordingLogger{}
+	logger := NewMultiLogger(first, second)
+
+	logger.Info("user_action", "user logged in", nil)
+	logger.ErrorContext(context.Background(), errors.New("boom"), "failed", nil)
+	logger.HTTPRequest("GET", "/health", 200, time.Millisecond, nil)
+
+	expected := []string{"Info:user_action", "ErrorContext:boom", "HTTPRequest:GET /health"}
+	for _, r := range []*recordingLogger{first, second} {
+		if len(r.calls) != len(expected) {
+			t.Fatalf("expected calls %v, got %v", expected, r.calls)
+		}
+		for i := range expected {
+			if r.calls[i] != expected[i] {
+				t.Errorf("expected calls %v, got %v", expected, r.calls)
+				break
+			}
+		}
+	}
+}
+
+func TestMultiLogger_JoinsErrors(t *testing.T) {
+	errFirst := errors.New("first failed")
+	errSecond := errors.New("second failed")
+	ok := &recordingLogger{}
+	logger := NewMultiLogger(&recordingLogger{err: errFirst}, ok, &recordingLogger{err: errSecond})
+
+	err := logger.Info("test_event", "message", nil)
+	if !errors.Is(err, errFirst) || !errors.Is(err, errSecond) {
+		t.Errorf("expected joined errors, got %v", err)
+	}
+	if len(ok.calls) != 1 {
+		t.Errorf("expected healthy logger to be called despite errors, got %v", ok.calls)
+	}
+}
+
+func TestMultiLogger_NoErrors(t *testing.T) {
+	logger := NewMultiLogger(NopLogger{}, NopLogger{})
+	if err := logger.Critical("system failure", nil); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestMultiLogger_WithClient(t *testing.T) {
+	client := NewClient("", "test-service")
+	logger := NewMultiLogger(client, NopLogger{})
+
+	if err := logger.Debug("debug", nil); err == nil {
+		t.Error("expected client error to be propagated")
+	}
+}
@@ -0,0 +1,177 @@
+package logging
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const defaultRequestIDHeader = "X-Request-ID"
+
+// defaultSkipPaths пути, которые не логируются по умолчанию
+var defaultSkipPaths = []string{"/health"}
+
+// HTTPMiddlewareConfig настройки middleware для входящих HTTP запросов
+type HTTPMiddlewareConfig struct {
+	// SkipPaths пути без логирования (по умолчанию /health).
+	// Пустой срез, а не nil, логирует все пути.
+	SkipPaths []string
+	// Skip дополнительное правило пропуска запроса
+	Skip func(r *http.Request) bool
+	// Route возвращает шаблон маршрута, например "/users/{id}". Вызывается
+	// после обработчика, поэтому может читать данные роутера. Шаблон,
+	// заданный обработчиком через SetRoute, имеет приоритет.
+	Route func(r *http.Request) string
+	// RequestIDHeader заголовок с ID запроса (по умолчанию X-Request-ID)
+	RequestIDHeader string
+	// TrustProxy берет IP клиента из X-Forwarded-For и X-Real-IP
+	TrustProxy bool
+}
+
+// routeHolder шаблон маршрута, который обработчик сообщает middleware
+type routeHolder struct {
+	route string
+}
+
+// SetRoute сообщает HTTPMiddleware шаблон маршрута текущего запроса.
+// Шаблон логируется вместо пути, чтобы не плодить уникальные значения.
+func SetRoute(r *http.Request, route string) {
+	if holder, ok := r.Context().Value(routeKey).(*routeHolder); ok {
+		holder.route = route
+	}
+}
+
+// HTTPMiddleware логирует каждый входящий запрос событием http_request:
+// метод, маршрут, статус, длительность, размер ответа, IP клиента,
+// User-Agent и ID запроса. ID запроса также сохраняется в контексте.
+func (c *Client) HTTPMiddleware(cfg HTTPMiddlewareConfig) func(http.Handler) http.Handler {
+	if cfg.SkipPaths == nil {
+		cfg.SkipPaths = defaultSkipPaths
+	}
+	if cfg.RequestIDHeader == "" {
+		cfg.RequestIDHeader = defaultRequestIDHeader
+	}
+	skip := make(map[string]bool, len(cfg.SkipPaths))
+	for _, path := range cfg.SkipPaths {
+		skip[path] = true
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if skip[r.URL.Path] || (cfg.Skip != nil && cfg.Skip(r)) || !c.Enabled(LevelInfo) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			start := time.Now()
+			ctx := r.Context()
+			if requestID := r.Header.Get(cfg.RequestIDHeader); requestID != "" {
+				ctx = ContextWithRequestID(ctx, requestID)
+			}
+			holder := &routeHolder{}
+			ctx = context.WithValue(ctx, routeKey, holder)
+			r = r.WithContext(ctx)
+
+			rw := &responseWriter{ResponseWriter: w}
+			next.ServeHTTP(rw, r)
+
+			route := holder.route
+			if route == "" && cfg.Route != nil {
+				route = cfg.Route(r)
+			}
+			if route == "" {
+				route = r.URL.Path
+			}
+
+			metadata := map[string]interface{}{
+				"bytes_written": rw.bytes,
+				"remote_ip":     remoteIP(r, cfg.TrustProxy),
+			}
+			if ua := r.UserAgent(); ua != "" {
+				metadata["user_agent"] = ua
+			}
+			// Клиент мог отключиться, а такие запросы как раз нужно залогировать
+			c.HTTPRequestContext(context.WithoutCancel(ctx), r.Method, route, rw.status(), time.Since(start), metadata)
+		})
+	}
+}
+
+// remoteIP возвращает IP клиента
+func remoteIP(r *http.Request, trustProxy bool) string {
+	if trustProxy {
+		if forwarded := r.Header.Get("X-Forwarded-For"); forwarded != "" {
+			first, _, _ := strings.Cut(forwarded, ",")
+			return strings.TrimSpace(first)
+		}
+		if realIP := r.Header.Get("X-Real-IP"); realIP != "" {
+			return strings.TrimSpace(realIP)
+		}
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// responseWriter запоминает статус и размер ответа
+type responseWriter struct {
+	http.ResponseWriter
+	code  int
+	bytes int64
+}
+
+func (w *responseWriter) WriteHeader(code int) {
+	// Информационные ответы 1xx не финальные, кроме смены протокола
+	if w.code == 0 && (code >= http.StatusOK || code == http.StatusSwitchingProtocols) {
+		w.code = code
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *responseWriter) Write(p []byte) (int, error) {
+	if w.code == 0 {
+		w.code = http.StatusOK
+	}
+	n, err := w.ResponseWriter.Write(p)
+	w.bytes += int64(n)
+	return n, err
+}
+
+// status возвращает отправленный статус; без явного статуса net/http отвечает 200
+func (w *responseWriter) status() int {
+	if w.code == 0 {
+		return http.StatusOK
+	}
+	return w.code
+}
+
+// Flush передает Flush исходному ResponseWriter, если он его поддерживает
+func (w *responseWriter) Flush() {
+	if w.code == 0 {
+		w.code = http.StatusOK
+	}
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Hijack нужен для WebSocket и других протоколов поверх HTTP
+func (w *responseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, http.ErrNotSupported
+	}
+	if w.code == 0 {
+		w.code = http.StatusSwitchingProtocols
+	}
+	return hijacker.Hijack()
+}
+
+// Unwrap позволяет http.ResponseController найти исходный ResponseWriter
+func (w *responseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
@@ -0,0 +1,148 @@
+package logging
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestHTTPMiddleware_LogsRequest(t *testing.T) {
+	server, received := captureServer(t)
+	client := NewClient(server.URL, "test-service")
+
+	var ctxRequestID string
+	handler := client.HTTPMiddleware(HTTPMiddlewareConfig{})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctxRequestID, _ = RequestIDFromContext(r.Context())
+		SetRoute(r, "/users/{id}")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte("hello"))
+	}))
+
+	req := httptest.NewRequest(http.MethodPost, "/users/42", nil)
+	req.RemoteAddr = "10.0.0.1:5555"
+	req.Header.Set("User-Agent", "test-agent")
+	req.Header.Set("X-Request-ID", "req-1")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	events := received()
+	if len(events) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(events))
+	}
+	m := events[0].Metadata
+	if events[0].Event != "http_request" {
+		t.Errorf("expected event http_request, got %s", events[0].Event)
+	}
+	if m["path"] != "/users/{id}" || m["method"] != "POST" {
+		t.Errorf("expected POST /users/{id}, got %v %v", m["method"], m["path"])
+	}
+	if m["status_code"] != float64(201) {
+		t.Errorf("expected status_code 201, got %v", m["status_code"])
+	}
+	if m["bytes_written"] != float64(5) {
+		t.Errorf("expected bytes_written 5, got %v", m["bytes_written"])
+	}
+	if m["remote_ip"] != "10.0.0.1" || m["user_agent"] != "test-agent" {
+		t.Errorf("unexpected client metadata: %v", m)
+	}
+	if m["request_id"] != "req-1" || ctxRequestID != "req-1" {
+		t.Errorf("expected request_id req-1 in metadata and context, got %v and %q", m["request_id"], ctxRequestID)
+	}
+}
+
+func TestHTTPMiddleware_LogsAbortedRequest(t *testing.T) {
+	server, received := captureServer(t)
+	client := NewClient(server.URL, "test-service")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	handler := client.HTTPMiddleware(HTTPMiddlewareConfig{})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Клиент отключился, пока запрос обрабатывался
+		cancel()
+	}))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/search", nil).WithContext(ctx))
+
+	if events := received(); len(events) != 1 {
+		t.Errorf("expected aborted request to be logged, got %d events", len(events))
+	}
+}
+
+func TestHTTPMiddleware_DefaultsAndRouteFunc(t *testing.T) {
+	server, received := captureServer(t)
+	client := NewClient(server.URL, "test-service")
+
+	handler := client.HTTPMiddleware(HTTPMiddlewareConfig{
+		Route: func(r *http.Request) string {
+			if strings.HasPrefix(r.URL.Path, "/flights/") {
+				return "/flights/{code}"
+			}
+			return ""
+		},
+	})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/flights/SU100", nil))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/search", nil))
+
+	events := received()
+	if len(events) != 2 {
+		t.Fatalf("expected 2 events, got %d", len(events))
+	}
+	if events[0].Metadata["path"] != "/flights/{code}" {
+		t.Errorf("expected route template, got %v", events[0].Metadata["path"])
+	}
+	if events[1].Metadata["path"] != "/search" {
+		t.Errorf("expected raw path fallback, got %v", events[1].Metadata["path"])
+	}
+	if events[0].Metadata["status_code"] != float64(200) {
+		t.Errorf("expected implicit status 200, got %v", events[0].Metadata["status_code"])
+	}
+}
+
+func TestHTTPMiddleware_SkipRules(t *testing.T) {
+	server, received := captureServer(t)
+	client := NewClient(server.URL, "test-service")
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+
+	handler := client.HTTPMiddleware(HTTPMiddlewareConfig{
+		Skip: func(r *http.Request) bool { return r.Method == http.MethodOptions },
+	})(next)
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/health", nil))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodOptions, "/search", nil))
+	if len(received()) != 0 {
+		t.Fatalf("expected skipped requests not to be logged, got %d", len(received()))
+	}
+
+	handler = client.HTTPMiddleware(HTTPMiddlewareConfig{SkipPaths: []string{}})(next)
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/health", nil))
+	if len(received()) != 1 {
+		t.Errorf("expected /health to be logged with empty SkipPaths, got %d", len(received()))
+	}
+}
+
+func TestHTTPMiddleware_TrustProxy(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.RemoteAddr = "10.0.0.1:5555"
+	req.Header.Set("X-Forwarded-For", "203.0.113.7, 10.0.0.2")
+
+	if ip := remoteIP(req, false); ip != "10.0.0.1" {
+		t.Errorf("expected 10.0.0.1 without proxy trust, got %s", ip)
+	}
+	if ip := remoteIP(req, true); ip != "203.0.113.7" {
+		t.Errorf("expected 203.0.113.7 from X-Forwarded-For, got %s", ip)
+	}
+}
+
+func TestResponseWriter_SupportsFlush(t *testing.T) {
+	rec := httptest.NewRecorder()
+	rw := &responseWriter{ResponseWriter: rec}
+
+	if err := http.NewResponseController(rw).Flush(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !rec.Flushed {
+		t.Error("expected underlying writer to be flushed")
+	}
+	if _, _, err := rw.Hijack(); err == nil {
+		t.Error("expected hijack to be unsupported by recorder")
+	}
+}
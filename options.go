@@ -0,0 +1,130 @@
+package logging
+
+import (
+	"net/http"
+	"time"
+)
+
+// Option настраивает клиент при создании через NewClient
+type Option func(*config)
+
+// WithHTTPClient задает HTTP клиент для отправки логов.
+// Переданный клиент не изменяется другими опциями.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(cfg *config) {
+		cfg.HTTPClient = httpClient
+	}
+}
+
+// WithTimeout задает таймаут HTTP запроса к logging-service
+func WithTimeout(timeout time.Duration) Option {
+	return func(cfg *config) {
+		cfg.Timeout = timeout
+	}
+}
+
+// WithTransport задает транспорт HTTP клиента
+func WithTransport(transport http.RoundTripper) Option {
+	return func(cfg *config) {
+		cfg.Transport = transport
+	}
+}
+
+// WithEndpointPath задает путь для отправки одного события вместо /log
+func WithEndpointPath(path string) Option {
+	return func(cfg *config) {
+		cfg.EndpointPath = path
+	}
+}
+
+// WithUserAgent задает заголовок User-Agent запросов к logging-service
+func WithUserAgent(userAgent string) Option {
+	return func(cfg *config) {
+		cfg.UserAgent = userAgent
+	}
+}
+
+// WithDefaultMetadata добавляет метаданные к каждому событию.
+// Метаданные вызова имеют приоритет над значениями по умолчанию.
+func WithDefaultMetadata(metadata map[string]interface{}) Option {
+	return func(cfg *config) {
+		cfg.DefaultMetadata = metadata
+	}
+}
+
+// WithMinLevel отбрасывает события ниже указанного уровня
+func WithMinLevel(level Level) Option {
+	return func(cfg *config) {
+		cfg.MinLevel = level
+	}
+}
+
+// WithErrorHandler задает обработчик ошибок доставки событий
+func WithErrorHandler(handler func(err error, req LogRequest)) Option {
+	return func(cfg *config) {
+		cfg.ErrorHandler = handler
+	}
+}
+
+// WithRepanic повторяет панику после логирования в RecoverMiddleware и Go
+func WithRepanic(repanic bool) Option {
+	return func(cfg *config) {
+		cfg.Repanic = repanic
+	}
+}
+
+// WithEnvironment задает название окружения в каждом событии
+func WithEnvironment(environment string) Option {
+	return func(cfg *config) {
+		cfg.Environment = environment
+	}
+}
+
+// WithInstanceID задает ID экземпляра сервиса вместо случайного
+func WithInstanceID(instanceID string) Option {
+	return func(cfg *config) {
+		cfg.InstanceID = instanceID
+	}
+}
+
+// WithClock задает источник времени для меток событий, например в тестах
+func WithClock(now func() time.Time) Option {
+	return func(cfg *config) {
+		cfg.Clock = now
+	}
+}
+
+// WithAsync включает асинхронную доставку через очередь
+func WithAsync(async AsyncConfig) Option {
+	return func(cfg *config) {
+		cfg.Async = &async
+	}
+}
+
+// WithBatching включает пакетную отправку на bulk endpoint
+func WithBatching(batch BatchConfig) Option {
+	return func(cfg *config) {
+		cfg.Batch = &batch
+	}
+}
+
+// WithRetry включает повторные попытки при временных ошибках
+func WithRetry(policy RetryPolicy) Option {
+	return func(cfg *config) {
+		cfg.Retry = &policy
+	}
+}
+
+// WithCircuitBreaker включает circuit breaker вокруг logging-service
+func WithCircuitBreaker(breaker BreakerConfig) Option {
+	return func(cfg *config) {
+		cfg.Breaker = &breaker
+	}
+}
+
+// WithSpool сохраняет недоставленные события на диск
+func WithSpool(spool SpoolConfig) Option {
+	return func(cfg *config) {
+		cfg.Spool = &spool
+	}
+}
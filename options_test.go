@@ -0,0 +1,163 @@
+package logging
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// roundTripFunc адаптер функции к http.RoundTripper
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func TestNewClient_Defaults(t *testing.T) {
+	client := NewClient("http://localhost:8080", "test-service")
+
+	if client.httpClient.Timeout != 10*time.Second {
+		t.Errorf("expected timeout 10s, got %v", client.httpClient.Timeout)
+	}
+	if client.endpointPath != "/log" {
+		t.Errorf("expected endpoint /log, got %s", client.endpointPath)
+	}
+}
+
+func TestWithTimeoutAndHTTPClient(t *testing.T) {
+	custom := &http.Client{Timeout: time.Minute}
+	client := NewClient("http://localhost:8080", "test-service",
+		WithHTTPClient(custom),
+		WithTimeout(3*time.Second),
+	)
+
+	if client.httpClient.Timeout != 3*time.Second {
+		t.Errorf("expected timeout 3s, got %v", client.httpClient.Timeout)
+	}
+	if custom.Timeout != time.Minute {
+		t.Errorf("expected user client to stay unchanged, got %v", custom.Timeout)
+	}
+}
+
+func TestWithTransport(t *testing.T) {
+	var requested string
+	transport := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		requested = r.URL.String()
+		return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody, Header: http.Header{}}, nil
+	})
+
+	client := NewClient("http://logging-service", "test-service", WithTransport(transport))
+	if err := client.Info("test_event", "test message", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if requested != "http://logging-service/log" {
+		t.Errorf("expected request to http://logging-service/log, got %s", requested)
+	}
+}
+
+func TestWithEndpointPathAndUserAgent(t *testing.T) {
+	var path, userAgent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		userAgent = r.Header.Get("User-Agent")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-service",
+		WithEndpointPath("/api/v2/log"),
+		WithUserAgent("telegram-poller/1.0"),
+	)
+	client.Info("test_event", "test message", nil)
+
+	if path != "/api/v2/log" {
+		t.Errorf("expected path /api/v2/log, got %s", path)
+	}
+	if userAgent != "telegram-poller/1.0" {
+		t.Errorf("expected user agent telegram-poller/1.0, got %s", userAgent)
+	}
+}
+
+func TestWithDefaultMetadata(t *testing.T) {
+	var receivedPayload LogRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&receivedPayload)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	defaults := map[string]interface{}{"region": "eu", "version": "default"}
+	client := NewClient(server.URL, "test-service", WithDefaultMetadata(defaults))
+	defaults["region"] = "changed after construction"
+
+	client.ServiceStart("v1.0.0", "service started")
+
+	if receivedPayload.Metadata["region"] != "eu" {
+		t.Errorf("expected region eu, got %v", receivedPayload.Metadata["region"])
+	}
+	if receivedPayload.Metadata["version"] != "v1.0.0" {
+		t.Errorf("expected call metadata to win, got %v", receivedPayload.Metadata["version"])
+	}
+}
+
+func TestWithMinLevel(t *testing.T) {
+	var levels []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload LogRequest
+		json.NewDecoder(r.Body).Decode(&payload)
+		levels = append(levels, payload.Level)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-service", WithMinLevel(LevelWarning))
+	client.Debug("debug", nil)
+	client.Info("test_event", "info", nil)
+	client.Warning("warning", nil)
+	client.Critical("critical", nil)
+
+	if len(levels) != 2 || levels[0] != "WARNING" || levels[1] != "CRITICAL" {
+		t.Errorf("expected WARNING and CRITICAL only, got %v", levels)
+	}
+}
+
+func TestWithErrorHandler(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	var handledErr error
+	var handledReq LogRequest
+	client := NewClient(server.URL, "test-service", WithErrorHandler(func(err error, req LogRequest) {
+		handledErr = err
+		handledReq = req
+	}))
+
+	err := client.Warning("slow response", nil)
+	if err == nil {
+		t.Fatal("expected error for HTTP 500 response")
+	}
+	if !errors.Is(handledErr, err) {
+		t.Errorf("expected handler to receive %v, got %v", err, handledErr)
+	}
+	if handledReq.Event != "warning_event" {
+		t.Errorf("expected handler to receive warning_event, got %s", handledReq.Event)
+	}
+}
+
+func TestWithAsyncOption(t *testing.T) {
+	client := NewClient("http://localhost:8080", "test-service", WithAsync(AsyncConfig{QueueSize: 5}))
+	defer client.Close(context.Background())
+
+	if client.queue == nil {
+		t.Fatal("expected async queue to be configured")
+	}
+	if cap(client.queue.events) != 5 {
+		t.Errorf("expected queue size 5, got %d", cap(client.queue.events))
+	}
+}
@@ -0,0 +1,82 @@
+package logging
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"runtime/debug"
+)
+
+// RecoverMiddleware перехватывает панику обработчика, логирует CRITICAL
+// событие со стеком и отвечает 500, если ответ еще не начат. Событие
+// отправляется синхронно в обход очереди и пакетов. С WithRepanic паника
+// после логирования повторяется.
+func (c *Client) RecoverMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rw := &responseWriter{ResponseWriter: w}
+		defer func() {
+			value := recover()
+			if value == nil {
+				return
+			}
+			if value == http.ErrAbortHandler {
+				// Штатное прерывание ответа, net/http не логирует его
+				panic(value)
+			}
+
+			c.reportPanic(r.Context(), value, map[string]interface{}{
+				"method": r.Method,
+				"path":   r.URL.Path,
+			})
+			if c.repanic {
+				panic(value)
+			}
+			if rw.code == 0 {
+				http.Error(rw, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			}
+		}()
+		next.ServeHTTP(rw, r)
+	})
+}
+
+// Go запускает fn в горутине и логирует ее панику CRITICAL событием.
+// Без WithRepanic паника не завершает процесс.
+func (c *Client) Go(fn func()) {
+	go func() {
+		defer func() {
+			if value := recover(); value != nil {
+				c.reportPanic(context.Background(), value, nil)
+				if c.repanic {
+					panic(value)
+				}
+			}
+		}()
+		fn()
+	}()
+}
+
+// reportPanic синхронно отправляет событие о панике в обход очереди и пакетов,
+// чтобы оно не потерялось, если процесс сейчас завершится
+func (c *Client) reportPanic(ctx context.Context, value interface{}, metadata map[string]interface{}) error {
+	if !c.Enabled(LevelCritical) {
+		return nil
+	}
+	baseMetadata := map[string]interface{}{
+		"panic":      fmt.Sprint(value),
+		"panic_type": fmt.Sprintf("%T", value),
+		"stack":      string(debug.Stack()),
+	}
+	finalMetadata := c.mergeMetadata(baseMetadata, metadata)
+
+	// Паника могла случиться из-за отмены запроса, событие все равно нужно
+	ctx = context.WithoutCancel(ctx)
+	payload, err := c.newRequest(ctx, LevelCritical, "critical_event", fmt.Sprintf("panic: %v", value), finalMetadata)
+	if err != nil {
+		return err
+	}
+	if !c.stats.pending.add(payload.Sequence) {
+		return ErrClientClosed
+	}
+	defer c.stats.pending.done(payload.Sequence)
+	return c.deliver(ctx, payload)
+}
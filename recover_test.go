@@ -0,0 +1,103 @@
+package logging
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRecoverMiddleware_LogsPanicSynchronously(t *testing.T) {
+	server, received := captureServer(t)
+	// Асинхронный клиент с пакетами: событие о панике должно обойти и очередь, и пакет
+	client := NewClient(server.URL, "test-service",
+		WithAsync(AsyncConfig{}),
+		WithBatching(BatchConfig{MaxEvents: 100}),
+	)
+	defer client.Close(context.Background())
+
+	handler := client.RecoverMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("nil map write")
+	}))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/search", nil))
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("expected status 500, got %d", rec.Code)
+	}
+	events := received()
+	if len(events) != 1 {
+		t.Fatalf("expected panic event before handler returned, got %d", len(events))
+	}
+	req := events[0]
+	if req.Level != "CRITICAL" || req.Message != "panic: nil map write" {
+		t.Errorf("unexpected event: %s %q", req.Level, req.Message)
+	}
+	if req.Metadata["panic"] != "nil map write" || req.Metadata["panic_type"] != "string" {
+		t.Errorf("unexpected panic metadata: %v", req.Metadata)
+	}
+	if stack, _ := req.Metadata["stack"].(string); !strings.Contains(stack, "TestRecoverMiddleware_LogsPanicSynchronously") {
+		t.Errorf("expected stack to include the panicking handler, got %q", stack)
+	}
+	if req.Metadata["path"] != "/search" {
+		t.Errorf("expected path /search, got %v", req.Metadata["path"])
+	}
+}
+
+func TestRecoverMiddleware_Repanic(t *testing.T) {
+	server, received := captureServer(t)
+	client := NewClient(server.URL, "test-service", WithRepanic(true))
+
+	handler := client.RecoverMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	}))
+
+	defer func() {
+		if value := recover(); value != "boom" {
+			t.Errorf("expected panic to be repeated, got %v", value)
+		}
+		if len(received()) != 1 {
+			t.Errorf("expected panic to be logged before repanic, got %d events", len(received()))
+		}
+	}()
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+}
+
+func TestRecoverMiddleware_AbortHandlerNotLogged(t *testing.T) {
+	server, received := captureServer(t)
+	client := NewClient(server.URL, "test-service")
+
+	handler := client.RecoverMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic(http.ErrAbortHandler)
+	}))
+
+	defer func() {
+		if value := recover(); value != http.ErrAbortHandler {
+			t.Errorf("expected ErrAbortHandler to propagate, got %v", value)
+		}
+		if len(received()) != 0 {
+			t.Errorf("expected no events, got %d", len(received()))
+		}
+	}()
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+}
+
+func TestGo_RecoversPanic(t *testing.T) {
+	server, received := captureServer(t)
+	client := NewClient(server.URL, "test-service")
+
+	client.Go(func() {
+		var m map[string]int
+		m["x"] = 1
+	})
+
+	waitFor(t, func() bool { return len(received()) == 1 })
+	req := received()[0]
+	if req.Level != "CRITICAL" {
+		t.Errorf("expected level CRITICAL, got %s", req.Level)
+	}
+	if !strings.Contains(req.Message, "nil map") {
+		t.Errorf("expected runtime panic message, got %q", req.Message)
+	}
+}
@@ -0,0 +1,135 @@
+package logging
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultRetryMaxAttempts    = 3
+	defaultRetryInitialBackoff = 100 * time.Millisecond
+	defaultRetryMaxBackoff     = 5 * time.Second
+	defaultRetryMultiplier     = 2.0
+	defaultRetryJitter         = 0.2
+	defaultRetryMaxElapsed     = 30 * time.Second
+)
+
+// RetryPolicy политика повторной отправки при временных ошибках.
+// Повторяются сетевые ошибки, ответы 5xx и 429; остальные 4xx - нет.
+type RetryPolicy struct {
+	MaxAttempts    int           // всего попыток, включая первую (по умолчанию 3)
+	InitialBackoff time.Duration // пауза перед первым повтором (по умолчанию 100ms)
+	MaxBackoff     time.Duration // максимальная пауза (по умолчанию 5s)
+	Multiplier     float64       // множитель экспоненты (по умолчанию 2)
+	Jitter         float64       // доля случайного разброса паузы 0..1 (по умолчанию 0.2)
+	MaxElapsed     time.Duration // общий лимит времени на все попытки (по умолчанию 30s)
+}
+
+// withDefaults подставляет значения по умолчанию для незаданных полей
+func (p RetryPolicy) withDefaults() RetryPolicy {
+	if p.MaxAttempts <= 0 {
+		p.MaxAttempts = defaultRetryMaxAttempts
+	}
+	if p.InitialBackoff <= 0 {
+		p.InitialBackoff = defaultRetryInitialBackoff
+	}
+	if p.MaxBackoff <= 0 {
+		p.MaxBackoff = defaultRetryMaxBackoff
+	}
+	if p.Multiplier < 1 {
+		p.Multiplier = defaultRetryMultiplier
+	}
+	if p.Jitter <= 0 || p.Jitter > 1 {
+		p.Jitter = defaultRetryJitter
+	}
+	if p.MaxElapsed <= 0 {
+		p.MaxElapsed = defaultRetryMaxElapsed
+	}
+	return p
+}
+
+// do выполняет attempt, повторяя его по политике
+func (p *RetryPolicy) do(ctx context.Context, stats *clientStats, attempt func() error) error {
+	start := time.Now()
+	backoff := p.InitialBackoff
+
+	for n := 1; ; n++ {
+		err := attempt()
+		if err == nil || n >= p.MaxAttempts || ctx.Err() != nil || !isRetryable(err) {
+			return err
+		}
+
+		wait := p.jittered(backoff)
+		var statusErr *StatusError
+		if errors.As(err, &statusErr) && statusErr.RetryAfter > wait {
+			wait = statusErr.RetryAfter
+		}
+		if time.Since(start)+wait > p.MaxElapsed {
+			return err
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		}
+
+		stats.retried.Add(1)
+		backoff = time.Duration(float64(backoff) * p.Multiplier)
+		if backoff > p.MaxBackoff {
+			backoff = p.MaxBackoff
+		}
+	}
+}
+
+// jittered добавляет к паузе случайный разброс ±Jitter
+func (p *RetryPolicy) jittered(backoff time.Duration) time.Duration {
+	delta := p.Jitter * (2*rand.Float64() - 1)
+	return time.Duration(float64(backoff) * (1 + delta))
+}
+
+// isRetryable определяет, имеет ли смысл повторять отправку
+func isRetryable(err error) bool {
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.Retryable()
+	}
+	// *url.Error оборачивает и сетевые ошибки http.Client.Do, и ошибки
+	// разбора адреса, поэтому решение принимается по вложенной ошибке
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		err = urlErr.Err
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+	// Соединение закрыто сервером до ответа
+	return errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// parseRetryAfter разбирает Retry-After в секундах или в формате HTTP даты
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if at, err := http.ParseTime(value); err == nil && at.After(now) {
+		return at.Sub(now)
+	}
+	return 0
+}
@@ -0,0 +1,204 @@
+package logging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fastRetry политика с короткими паузами для тестов
+var fastRetry = &RetryPolicy{
+	MaxAttempts:    4,
+	InitialBackoff: time.Millisecond,
+	MaxBackoff:     5 * time.Millisecond,
+}
+
+// flakyServer отвечает статусом failStatus первые failures запросов
+func flakyServer(failures int32, failStatus int, header http.Header) (*httptest.Server, *atomic.Int32) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) <= failures {
+			for k, v := range header {
+				w.Header()[k] = v
+			}
+			w.WriteHeader(failStatus)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	return server, &attempts
+}
+
+func TestRetry_ServerErrorThenSuccess(t *testing.T) {
+	server, attempts := flakyServer(2, http.StatusBadGateway, nil)
+	defer server.Close()
+
+	client := newClient(server.URL, "test-service", config{Retry: fastRetry})
+	if err := client.Info("test_event", "test message", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if attempts.Load() != 3 {
+		t.Errorf("expected 3 attempts, got %d", attempts.Load())
+	}
+	if stats := client.Stats(); stats.Retried != 2 || stats.Sent != 1 {
+		t.Errorf("expected Retried 2 and Sent 1, got %+v", stats)
+	}
+}
+
+func TestRetry_TooManyRequestsIsRetried(t *testing.T) {
+	server, attempts := flakyServer(1, http.StatusTooManyRequests, nil)
+	defer server.Close()
+
+	client := newClient(server.URL, "test-service", config{Retry: fastRetry})
+	if err := client.Info("test_event", "test message", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if attempts.Load() != 2 {
+		t.Errorf("expected 2 attempts, got %d", attempts.Load())
+	}
+}
+
+func TestRetry_ClientErrorIsNotRetried(t *testing.T) {
+	server, attempts := flakyServer(10, http.StatusBadRequest, nil)
+	defer server.Close()
+
+	client := newClient(server.URL, "test-service", config{Retry: fastRetry})
+	if err := client.Info("test_event", "test message", nil); err == nil {
+		t.Fatal("expected error for HTTP 400 response")
+	}
+	if attempts.Load() != 1 {
+		t.Errorf("expected 1 attempt, got %d", attempts.Load())
+	}
+}
+
+func TestRetry_MaxAttempts(t *testing.T) {
+	server, attempts := flakyServer(10, http.StatusServiceUnavailable, nil)
+	defer server.Close()
+
+	client := newClient(server.URL, "test-service", config{Retry: fastRetry})
+	err := client.Info("test_event", "test message", nil)
+
+	var statusErr *StatusError
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("expected status 503 error, got %v", err)
+	}
+	if attempts.Load() != 4 {
+		t.Errorf("expected 4 attempts, got %d", attempts.Load())
+	}
+}
+
+func TestRetry_NetworkError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	url := server.URL
+	server.Close()
+
+	client := newClient(url, "test-service", config{Retry: fastRetry})
+	if err := client.Info("test_event", "test message", nil); err == nil {
+		t.Fatal("expected error for unreachable server")
+	}
+	if stats := client.Stats(); stats.Retried != 3 {
+		t.Errorf("expected 3 retries, got %d", stats.Retried)
+	}
+}
+
+func TestRetry_MalformedBaseURLIsNotRetried(t *testing.T) {
+	client := newClient("://logging-service", "test-service", config{Retry: fastRetry})
+	if err := client.Info("test_event", "test message", nil); err == nil {
+		t.Fatal("expected error for malformed baseURL")
+	}
+	if stats := client.Stats(); stats.Retried != 0 {
+		t.Errorf("expected no retries, got %d", stats.Retried)
+	}
+}
+
+func TestRetry_RetryAfterExceedsMaxElapsed(t *testing.T) {
+	header := http.Header{"Retry-After": []string{"2"}}
+	server, attempts := flakyServer(10, http.StatusTooManyRequests, header)
+	defer server.Close()
+
+	policy := *fastRetry
+	policy.MaxElapsed = 500 * time.Millisecond
+	client := newClient(server.URL, "test-service", config{Retry: &policy})
+
+	start := time.Now()
+	if err := client.Info("test_event", "test message", nil); err == nil {
+		t.Fatal("expected error")
+	}
+	if elapsed := time.Since(start); elapsed > 400*time.Millisecond {
+		t.Errorf("expected to give up without waiting, took %v", elapsed)
+	}
+	if attempts.Load() != 1 {
+		t.Errorf("expected 1 attempt, got %d", attempts.Load())
+	}
+}
+
+func TestRetry_StopsOnContextCancel(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 5, InitialBackoff: time.Hour}
+	policy = policy.withDefaults()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+	err := policy.do(ctx, &clientStats{}, func() error {
+		calls++
+		return &StatusError{StatusCode: http.StatusInternalServerError}
+	})
+	if err == nil || calls != 1 {
+		t.Errorf("expected single failed attempt, got %d calls and %v", calls, err)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		value    string
+		expected time.Duration
+	}{
+		{"", 0},
+		{"3", 3 * time.Second},
+		{"-1", 0},
+		{now.Add(10 * time.Second).Format(http.TimeFormat), 10 * time.Second},
+		{now.Add(-10 * time.Second).Format(http.TimeFormat), 0},
+		{"garbage", 0},
+	}
+	for _, tt := range tests {
+		if got := parseRetryAfter(tt.value, now); got != tt.expected {
+			t.Errorf("parseRetryAfter(%q): expected %v, got %v", tt.value, tt.expected, got)
+		}
+	}
+}
+
+func TestIsRetryable(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected bool
+	}{
+		{&StatusError{StatusCode: 500}, true},
+		{&StatusError{StatusCode: 502}, true},
+		{&StatusError{StatusCode: 429}, true},
+		{&StatusError{StatusCode: 400}, false},
+		{&StatusError{StatusCode: 404}, false},
+		{fmt.Errorf("wrapped: %w", &StatusError{StatusCode: 503}), true},
+		{errors.New("failed to build log request"), false},
+		{&url.Error{Op: "Post", URL: "http://logging", Err: io.EOF}, true},
+		{&url.Error{Op: "Post", URL: "http://logging", Err: &net.OpError{Op: "dial", Err: errors.New("connection refused")}}, true},
+		{&url.Error{Op: "parse", URL: "://logging", Err: errors.New("missing protocol scheme")}, false},
+	}
+	for _, tt := range tests {
+		if got := isRetryable(tt.err); got != tt.expected {
+			t.Errorf("isRetryable(%v): expected %v, got %v", tt.err, tt.expected, got)
+		}
+	}
+}
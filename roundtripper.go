@@ -0,0 +1,119 @@
+package logging
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// telegramTokenPattern токен бота в пути Telegram Bot API: /bot<token>/getUpdates
+var telegramTokenPattern = regexp.MustCompile(`/bot\d+:[A-Za-z0-9_-]+`)
+
+// RoundTripperConfig настройки транспорта, который логирует внешние вызовы
+type RoundTripperConfig struct {
+	// Base транспорт для выполнения запросов (по умолчанию http.DefaultTransport)
+	Base http.RoundTripper
+	// APINames названия API по хосту, например "api.telegram.org": "telegram".
+	// Для неизвестных хостов названием служит сам хост.
+	APINames map[string]string
+	// RedactPath дополнительно скрывает секреты в пути. Токен бота Telegram
+	// и строка запроса скрываются всегда.
+	RedactPath func(path string) string
+}
+
+// apiTransport http.RoundTripper, который логирует каждый вызов событием external_api
+type apiTransport struct {
+	client      *Client
+	cfg         RoundTripperConfig
+	serviceHost string
+}
+
+// RoundTripper возвращает транспорт для http.Client, который логирует каждый
+// вызов событием external_api: название API, endpoint без секретов, статус
+// и время до получения заголовков ответа. Ошибки транспорта логируются
+// уровнем ERROR.
+//
+//	httpClient := &http.Client{Transport: logger.RoundTripper(logging.RoundTripperConfig{
+//		APINames: map[string]string{"api.telegram.org": "telegram"},
+//	})}
+func (c *Client) RoundTripper(cfg RoundTripperConfig) http.RoundTripper {
+	if cfg.Base == nil {
+		cfg.Base = http.DefaultTransport
+	}
+	t := &apiTransport{client: c, cfg: cfg}
+	if u, err := url.Parse(c.baseURL); err == nil {
+		t.serviceHost = u.Host
+	}
+	return t
+}
+
+// RoundTrip реализует http.RoundTripper
+func (t *apiTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// Запросы к самому logging-service не логируются, иначе получится петля.
+	// Уровень не проверяется заранее: ошибка транспорта логируется как ERROR
+	// и должна дойти даже при минимальном уровне выше INFO.
+	if req.URL.Host == t.serviceHost {
+		return t.cfg.Base.RoundTrip(req)
+	}
+
+	start := time.Now()
+	resp, err := t.cfg.Base.RoundTrip(req)
+	duration := time.Since(start)
+
+	apiName := t.apiName(req.URL.Host)
+	endpoint := t.endpoint(req.URL)
+	// Событие логируется и для отмененного запроса, значения контекста сохраняются
+	ctx := context.WithoutCancel(req.Context())
+	metadata := map[string]interface{}{"method": req.Method}
+
+	if err != nil {
+		message := strings.ReplaceAll(err.Error(), req.URL.String(), endpoint)
+		t.client.externalAPIFailure(ctx, apiName, endpoint, duration, message, metadata)
+		return resp, err
+	}
+	t.client.ExternalAPIContext(ctx, apiName, endpoint, resp.StatusCode, duration, metadata)
+	return resp, nil
+}
+
+// apiName возвращает название API для хоста
+func (t *apiTransport) apiName(host string) string {
+	if name, ok := t.cfg.APINames[host]; ok {
+		return name
+	}
+	if hostname, _, ok := strings.Cut(host, ":"); ok {
+		if name, ok := t.cfg.APINames[hostname]; ok {
+			return name
+		}
+	}
+	return host
+}
+
+// endpoint возвращает URL вызова без строки запроса и секретов в пути
+func (t *apiTransport) endpoint(u *url.URL) string {
+	path := telegramTokenPattern.ReplaceAllString(u.EscapedPath(), "/bot<redacted>")
+	if t.cfg.RedactPath != nil {
+		path = t.cfg.RedactPath(path)
+	}
+	return u.Scheme + "://" + u.Host + path
+}
+
+// externalAPIFailure логирует вызов внешнего API, завершившийся ошибкой транспорта
+func (c *Client) externalAPIFailure(ctx context.Context, apiName, endpoint string, duration time.Duration, errMessage string, metadata map[string]interface{}) error {
+	if !c.Enabled(LevelError) {
+		return nil
+	}
+	baseMetadata := map[string]interface{}{
+		"api_name":    apiName,
+		"endpoint":    endpoint,
+		"status_code": 0,
+		"duration_ms": duration.Milliseconds(),
+		"error":       errMessage,
+	}
+	finalMetadata := c.mergeMetadata(baseMetadata, metadata)
+	message := fmt.Sprintf("API call to %s failed", apiName)
+	return c.sendLogContext(ctx, LevelError, "external_api", message, finalMetadata)
+}
@@ -0,0 +1,131 @@
+package logging
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRoundTripper_LogsCall(t *testing.T) {
+	logServer, received := captureServer(t)
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer api.Close()
+
+	client := NewClient(logServer.URL, "test-service")
+	host := strings.TrimPrefix(api.URL, "http://")
+	httpClient := &http.Client{Transport: client.RoundTripper(RoundTripperConfig{
+		APINames: map[string]string{host: "telegram"},
+	})}
+
+	ctx := ContextWithChatID(context.Background(), 42)
+	req, _ := http.NewRequestWithContext(ctx, http.MethodPost, api.URL+"/bot123456:ABC-def_9/sendMessage?chat_id=42&text=hi", nil)
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+
+	events := received()
+	if len(events) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(events))
+	}
+	m := events[0].Metadata
+	if events[0].Event != "external_api" || events[0].Level != "INFO" {
+		t.Errorf("expected INFO external_api, got %s %s", events[0].Level, events[0].Event)
+	}
+	if m["api_name"] != "telegram" {
+		t.Errorf("expected api_name telegram, got %v", m["api_name"])
+	}
+	if m["endpoint"] != api.URL+"/bot<redacted>/sendMessage" {
+		t.Errorf("expected redacted endpoint, got %v", m["endpoint"])
+	}
+	if m["status_code"] != float64(202) || m["method"] != "POST" {
+		t.Errorf("unexpected call metadata: %v", m)
+	}
+	if m["chat_id"] != float64(42) {
+		t.Errorf("expected chat_id from request context, got %v", m["chat_id"])
+	}
+}
+
+func TestRoundTripper_LogsTransportError(t *testing.T) {
+	logServer, received := captureServer(t)
+	client := NewClient(logServer.URL, "test-service")
+
+	failing := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		return nil, errors.New("connection reset while calling " + r.URL.String())
+	})
+	httpClient := &http.Client{Transport: client.RoundTripper(RoundTripperConfig{
+		Base: failing,
+		RedactPath: func(path string) string {
+			return strings.Replace(path, "/secret-key", "/<key>", 1)
+		},
+	})}
+
+	_, err := httpClient.Get("https://api.travelpayouts.com/v1/secret-key/prices?token=abc")
+	if err == nil {
+		t.Fatal("expected transport error")
+	}
+
+	events := received()
+	if len(events) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(events))
+	}
+	m := events[0].Metadata
+	if events[0].Level != "ERROR" {
+		t.Errorf("expected level ERROR, got %s", events[0].Level)
+	}
+	if m["api_name"] != "api.travelpayouts.com" {
+		t.Errorf("expected host as api_name, got %v", m["api_name"])
+	}
+	if m["endpoint"] != "https://api.travelpayouts.com/v1/<key>/prices" {
+		t.Errorf("unexpected endpoint: %v", m["endpoint"])
+	}
+	if msg, _ := m["error"].(string); strings.Contains(msg, "token=abc") || strings.Contains(msg, "secret-key") {
+		t.Errorf("expected secrets to be removed from error, got %q", msg)
+	}
+}
+
+func TestRoundTripper_LogsTransportErrorAboveMinLevel(t *testing.T) {
+	logServer, received := captureServer(t)
+	client := NewClient(logServer.URL, "test-service", WithMinLevel(LevelError))
+
+	failing := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		return nil, errors.New("connection refused")
+	})
+	ok := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody, Request: r}, nil
+	})
+	(&http.Client{Transport: client.RoundTripper(RoundTripperConfig{Base: ok})}).Get("https://api.telegram.org/getMe")
+	(&http.Client{Transport: client.RoundTripper(RoundTripperConfig{Base: failing})}).Get("https://api.telegram.org/getMe")
+
+	events := received()
+	if len(events) != 1 || events[0].Level != "ERROR" {
+		t.Fatalf("expected only the transport error to be logged, got %v", events)
+	}
+}
+
+func TestRoundTripper_SkipsLoggingService(t *testing.T) {
+	logServer, received := captureServer(t)
+	client := NewClient(logServer.URL, "test-service")
+
+	httpClient := &http.Client{Transport: client.RoundTripper(RoundTripperConfig{})}
+	resp, err := httpClient.Get(logServer.URL + "/log")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+
+	if len(received()) != 1 {
+		t.Errorf("expected only the direct request to reach the service, got %d", len(received()))
+	}
+	for _, req := range received() {
+		if req.Event == "external_api" {
+			t.Errorf("expected calls to the logging service not to be logged")
+		}
+	}
+}
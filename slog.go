@@ -0,0 +1,236 @@
+package logging
+
+import (
+	"context"
+	"log/slog"
+	"runtime"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultSlogEventKey = "event"
+	defaultSlogEvent    = "log"
+)
+
+// SlogLevelCritical уровень slog, который соответствует LevelCritical
+const SlogLevelCritical = slog.LevelError + 4
+
+// SlogConfig настройки slog.Handler поверх клиента
+type SlogConfig struct {
+	EventKey     string // атрибут с названием события (по умолчанию "event")
+	DefaultEvent string // событие, если атрибут не задан (по умолчанию "log")
+	AddSource    bool   // добавлять file:line вызова в метаданные "source"
+}
+
+// SlogHandler реализует slog.Handler и отправляет записи через Client.
+// Атрибуты становятся метаданными, группы - вложенными объектами.
+type SlogHandler struct {
+	client *Client
+	cfg    SlogConfig
+
+	event  string                 // событие, привязанное через WithAttrs
+	attrs  map[string]interface{} // атрибуты WithAttrs
+	groups []string               // открытые группы WithGroup
+}
+
+var _ slog.Handler = (*SlogHandler)(nil)
+
+// SlogHandler возвращает slog.Handler, который отправляет записи в
+// logging-service тем же путем, что и методы клиента:
+//
+//	logger := slog.New(client.SlogHandler(logging.SlogConfig{}))
+//	logger.Info("search requested", "event", "user_action", "chat_id", chatID)
+func (c *Client) SlogHandler(cfg SlogConfig) *SlogHandler {
+	if cfg.EventKey == "" {
+		cfg.EventKey = defaultSlogEventKey
+	}
+	if cfg.DefaultEvent == "" {
+		cfg.DefaultEvent = defaultSlogEvent
+	}
+	return &SlogHandler{client: c, cfg: cfg}
+}
+
+// Enabled проверяет минимальный уровень клиента
+func (h *SlogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return h.client.Enabled(levelFromSlog(level))
+}
+
+// Handle преобразует запись в LogRequest и отправляет ее
+func (h *SlogHandler) Handle(ctx context.Context, r slog.Record) error {
+	metadata := make(map[string]interface{}, len(h.attrs)+r.NumAttrs()+1)
+	if h.cfg.AddSource && r.PC != 0 {
+		frames := runtime.CallersFrames([]uintptr{r.PC})
+		frame, _ := frames.Next()
+		metadata["source"] = frame.File + ":" + strconv.Itoa(frame.Line)
+	}
+	cloneInto(metadata, h.attrs)
+
+	event := h.event
+	attrs := make(map[string]interface{}, r.NumAttrs())
+	r.Attrs(func(a slog.Attr) bool {
+		if len(h.groups) == 0 {
+			if name, ok := h.eventAttr(a); ok {
+				event = name
+				return true
+			}
+		}
+		addSlogAttr(attrs, a)
+		return true
+	})
+	if len(attrs) > 0 {
+		cloneInto(groupMap(metadata, h.groups), attrs)
+	}
+	if event == "" {
+		event = h.cfg.DefaultEvent
+	}
+	if len(metadata) == 0 {
+		metadata = nil
+	}
+
+	payload, err := h.client.newRequest(ctx, levelFromSlog(r.Level), event, r.Message, metadata)
+	if err != nil {
+		return err
+	}
+	// Время записи slog точнее времени обработки; нулевое время не передается
+	payload.Timestamp = ""
+	if !r.Time.IsZero() {
+		payload.Timestamp = r.Time.UTC().Format(time.RFC3339Nano)
+	}
+	return h.client.dispatch(ctx, payload)
+}
+
+// WithAttrs возвращает обработчик с привязанными атрибутами
+func (h *SlogHandler) WithAttrs(as []slog.Attr) slog.Handler {
+	if len(as) == 0 {
+		return h
+	}
+	child := *h
+	attrs := make(map[string]interface{}, len(as))
+	for _, a := range as {
+		if len(h.groups) == 0 {
+			if name, ok := h.eventAttr(a); ok {
+				child.event = name
+				continue
+			}
+		}
+		addSlogAttr(attrs, a)
+	}
+	if len(attrs) > 0 {
+		child.attrs = make(map[string]interface{}, len(h.attrs)+len(attrs))
+		cloneInto(child.attrs, h.attrs)
+		cloneInto(groupMap(child.attrs, h.groups), attrs)
+	}
+	return &child
+}
+
+// WithGroup возвращает обработчик, который вкладывает следующие атрибуты в группу
+func (h *SlogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	child := *h
+	child.groups = append(h.groups[:len(h.groups):len(h.groups)], name)
+	return &child
+}
+
+// eventAttr сообщает, задает ли атрибут название события
+func (h *SlogHandler) eventAttr(a slog.Attr) (string, bool) {
+	if a.Key != h.cfg.EventKey {
+		return "", false
+	}
+	v := a.Value.Resolve()
+	if v.Kind() != slog.KindString {
+		return "", false
+	}
+	return v.String(), true
+}
+
+// levelFromSlog переводит уровень slog в уровень logging-service
+func levelFromSlog(level slog.Level) Level {
+	switch {
+	case level < slog.LevelInfo:
+		return LevelDebug
+	case level < slog.LevelWarn:
+		return LevelInfo
+	case level < slog.LevelError:
+		return LevelWarning
+	case level < SlogLevelCritical:
+		return LevelError
+	default:
+		return LevelCritical
+	}
+}
+
+// addSlogAttr добавляет атрибут в metadata, раскрывая группы.
+// Пустые атрибуты и пустые группы пропускаются.
+func addSlogAttr(metadata map[string]interface{}, a slog.Attr) {
+	a.Value = a.Value.Resolve()
+	if a.Equal(slog.Attr{}) {
+		return
+	}
+	if a.Value.Kind() != slog.KindGroup {
+		metadata[a.Key] = slogValue(a.Value)
+		return
+	}
+
+	group := a.Value.Group()
+	if a.Key == "" {
+		for _, ga := range group {
+			addSlogAttr(metadata, ga)
+		}
+		return
+	}
+	nested := make(map[string]interface{}, len(group))
+	for _, ga := range group {
+		addSlogAttr(nested, ga)
+	}
+	if len(nested) > 0 {
+		metadata[a.Key] = nested
+	}
+}
+
+// slogValue переводит значение slog в значение для JSON
+func slogValue(v slog.Value) interface{} {
+	switch v.Kind() {
+	case slog.KindDuration:
+		return v.Duration().String()
+	case slog.KindTime:
+		return v.Time().Format(time.RFC3339Nano)
+	case slog.KindAny:
+		if err, ok := v.Any().(error); ok {
+			return err.Error()
+		}
+	}
+	return v.Any()
+}
+
+// groupMap возвращает вложенный объект для пути групп, создавая недостающие
+func groupMap(metadata map[string]interface{}, groups []string) map[string]interface{} {
+	for _, name := range groups {
+		nested, ok := metadata[name].(map[string]interface{})
+		if !ok {
+			nested = make(map[string]interface{})
+			metadata[name] = nested
+		}
+		metadata = nested
+	}
+	return metadata
+}
+
+// cloneInto копирует src в dst; вложенные группы копируются, чтобы
+// обработчики с общими атрибутами не изменяли данные друг друга
+func cloneInto(dst, src map[string]interface{}) {
+	for k, v := range src {
+		if nested, ok := v.(map[string]interface{}); ok {
+			existing, ok := dst[k].(map[string]interface{})
+			if !ok {
+				existing = make(map[string]interface{}, len(nested))
+				dst[k] = existing
+			}
+			cloneInto(existing, nested)
+			continue
+		}
+		dst[k] = v
+	}
+}
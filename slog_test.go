@@ -0,0 +1,137 @@
+package logging
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"testing/slogtest"
+)
+
+func TestSlogHandler_Conformance(t *testing.T) {
+	server, received := captureServer(t)
+	client := NewClient(server.URL, "test-service")
+
+	results := func() []map[string]interface{} {
+		var ms []map[string]interface{}
+		for _, req := range received() {
+			m := map[string]interface{}{
+				slog.LevelKey:   req.Level,
+				slog.MessageKey: req.Message,
+			}
+			if req.Timestamp != "" {
+				m[slog.TimeKey] = req.Timestamp
+			}
+			for k, v := range req.Metadata {
+				m[k] = v
+			}
+			ms = append(ms, m)
+		}
+		return ms
+	}
+
+	if err := slogtest.TestHandler(client.SlogHandler(SlogConfig{}), results); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSlogHandler_ConvertsRecord(t *testing.T) {
+	server, received := captureServer(t)
+	client := NewClient(server.URL, "test-service")
+
+	logger := slog.New(client.SlogHandler(SlogConfig{AddSource: true})).
+		With("event", "search_completed", "chat_id", 42).
+		WithGroup("search")
+	logger.Warn("slow search", "duration", 1500, "err", errors.New("timeout"))
+
+	events := received()
+	if len(events) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(events))
+	}
+	req := events[0]
+	if req.Level != "WARNING" || req.Event != "search_completed" || req.Message != "slow search" {
+		t.Errorf("unexpected request: %s %s %s", req.Level, req.Event, req.Message)
+	}
+	if req.Service != "test-service" {
+		t.Errorf("expected service test-service, got %s", req.Service)
+	}
+	if req.Metadata["chat_id"] != float64(42) {
+		t.Errorf("expected chat_id 42, got %v", req.Metadata["chat_id"])
+	}
+	group, ok := req.Metadata["search"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected search group, got %v", req.Metadata)
+	}
+	if group["duration"] != float64(1500) || group["err"] != "timeout" {
+		t.Errorf("unexpected group attrs: %v", group)
+	}
+	if source, _ := req.Metadata["source"].(string); !strings.Contains(source, "slog_test.go:") {
+		t.Errorf("expected source in slog_test.go, got %v", req.Metadata["source"])
+	}
+}
+
+func TestSlogHandler_EventAttrAndDefault(t *testing.T) {
+	server, received := captureServer(t)
+	client := NewClient(server.URL, "test-service")
+
+	logger := slog.New(client.SlogHandler(SlogConfig{EventKey: "kind", DefaultEvent: "app_log"}))
+	logger.Info("started")
+	logger.Info("clicked", "kind", "user_action")
+
+	events := received()
+	if len(events) != 2 {
+		t.Fatalf("expected 2 events, got %d", len(events))
+	}
+	if events[0].Event != "app_log" {
+		t.Errorf("expected default event app_log, got %s", events[0].Event)
+	}
+	if events[1].Event != "user_action" {
+		t.Errorf("expected event user_action, got %s", events[1].Event)
+	}
+	if _, ok := events[1].Metadata["kind"]; ok {
+		t.Errorf("expected event attr to be removed from metadata, got %v", events[1].Metadata)
+	}
+}
+
+func TestSlogHandler_LevelsAndContext(t *testing.T) {
+	server, received := captureServer(t)
+	client := NewClient(server.URL, "test-service", WithMinLevel(LevelInfo))
+	logger := slog.New(client.SlogHandler(SlogConfig{}))
+
+	ctx := ContextWithRequestID(context.Background(), "req-1")
+	logger.DebugContext(ctx, "filtered")
+	logger.ErrorContext(ctx, "failed")
+	logger.Log(ctx, SlogLevelCritical, "down")
+
+	events := received()
+	if len(events) != 2 {
+		t.Fatalf("expected 2 events, got %d", len(events))
+	}
+	if events[0].Level != "ERROR" || events[1].Level != "CRITICAL" {
+		t.Errorf("expected ERROR and CRITICAL, got %s and %s", events[0].Level, events[1].Level)
+	}
+	if events[0].Metadata["request_id"] != "req-1" {
+		t.Errorf("expected request_id from context, got %v", events[0].Metadata["request_id"])
+	}
+}
+
+func TestLevelFromSlog(t *testing.T) {
+	tests := []struct {
+		level slog.Level
+		want  Level
+	}{
+		{slog.LevelDebug - 4, LevelDebug},
+		{slog.LevelDebug, LevelDebug},
+		{slog.LevelInfo, LevelInfo},
+		{slog.LevelWarn, LevelWarning},
+		{slog.LevelError, LevelError},
+		{slog.LevelError + 2, LevelError},
+		{SlogLevelCritical, LevelCritical},
+	}
+	for _, tt := range tests {
+		if got := levelFromSlog(tt.level); got != tt.want {
+			t.Errorf("expected %s for %s, got %s", tt.want, tt.level, got)
+		}
+	}
+}
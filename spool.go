@@ -0,0 +1,427 @@
+package logging
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultSpoolMaxSegmentBytes = 4 << 20
+	defaultSpoolMaxTotalBytes   = 64 << 20
+	defaultSpoolMinLevel        = LevelError
+	defaultSpoolReplayInterval  = 10 * time.Second
+
+	spoolSegmentExt = ".ndjson"
+	spoolOffsetExt  = ".offset"
+)
+
+// SpoolConfig настройки дискового буфера для недоставленных событий.
+// События пишутся в сегменты NDJSON и досылаются в исходном порядке,
+// в том числе после перезапуска процесса.
+type SpoolConfig struct {
+	Dir             string        // каталог сегментов (обязателен)
+	MaxSegmentBytes int64         // размер сегмента до ротации (по умолчанию 4 MiB)
+	MaxTotalBytes   int64         // общий лимит, старые сегменты удаляются (по умолчанию 64 MiB)
+	MinLevel        Level         // минимальный сохраняемый уровень (по умолчанию LevelError)
+	ReplayInterval  time.Duration // период попыток дослать события (по умолчанию 10s)
+}
+
+// spool дисковая очередь сегментов NDJSON
+type spool struct {
+	cfg  SpoolConfig
+	send func(ctx context.Context, data []byte) error
+
+	mu         sync.Mutex
+	ready      bool
+	active     segmentFile
+	activeSeq  uint64
+	activeSize int64
+	nextSeq    uint64
+
+	// replayMu не дает двум проходам досылки идти одновременно
+	replayMu sync.Mutex
+
+	started   bool
+	done      chan struct{}
+	finished  chan struct{}
+	closeOnce sync.Once
+}
+
+// segmentFile активный сегмент. В тестах подменяется, чтобы имитировать
+// ошибки записи.
+type segmentFile interface {
+	io.Writer
+	Sync() error
+	Truncate(size int64) error
+	Close() error
+}
+
+// newSpool создает spool. Фоновая досылка запускается через start.
+func newSpool(cfg SpoolConfig, send func(ctx context.Context, data []byte) error) *spool {
+	if cfg.MaxSegmentBytes <= 0 {
+		cfg.MaxSegmentBytes = defaultSpoolMaxSegmentBytes
+	}
+	if cfg.MaxTotalBytes <= 0 {
+		cfg.MaxTotalBytes = defaultSpoolMaxTotalBytes
+	}
+	if cfg.MinLevel == 0 {
+		cfg.MinLevel = defaultSpoolMinLevel
+	}
+	if cfg.ReplayInterval <= 0 {
+		cfg.ReplayInterval = defaultSpoolReplayInterval
+	}
+
+	s := &spool{
+		cfg:      cfg,
+		send:     send,
+		done:     make(chan struct{}),
+		finished: make(chan struct{}),
+	}
+	return s
+}
+
+// start запускает фоновую досылку
+func (s *spool) start() {
+	s.started = true
+	go s.run()
+}
+
+// accepts сообщает, нужно ли сохранять событие этого уровня
+func (s *spool) accepts(req LogRequest) bool {
+	level, err := ParseLevel(req.Level)
+	if err != nil {
+		level = LevelInfo
+	}
+	return level >= s.cfg.MinLevel
+}
+
+// init создает каталог и определяет номер следующего сегмента. Вызывается под mu.
+func (s *spool) init() error {
+	if s.ready {
+		return nil
+	}
+	if s.cfg.Dir == "" {
+		return fmt.Errorf("logging spool directory is empty")
+	}
+	if err := os.MkdirAll(s.cfg.Dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create spool directory: %w", err)
+	}
+	segments, err := s.listSegments()
+	if err != nil {
+		return err
+	}
+	// Новые события никогда не дописываются в сегменты прошлого процесса
+	s.nextSeq = 1
+	if len(segments) > 0 {
+		s.nextSeq = segments[len(segments)-1] + 1
+	}
+	s.ready = true
+	return nil
+}
+
+// write добавляет событие в активный сегмент
+func (s *spool) write(req LogRequest) error {
+	data, err := json.Marshal(req)
+	if err != nil {
+		return fmt.Errorf("failed to marshal log payload: %w", err)
+	}
+	data = append(data, '\n')
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.init(); err != nil {
+		return err
+	}
+	if s.active != nil && s.activeSize+int64(len(data)) > s.cfg.MaxSegmentBytes {
+		s.seal()
+	}
+	if s.active == nil {
+		f, err := os.OpenFile(s.segmentPath(s.nextSeq), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			return fmt.Errorf("failed to open spool segment: %w", err)
+		}
+		s.active = f
+		s.activeSeq = s.nextSeq
+		s.activeSize = 0
+		s.nextSeq++
+	}
+
+	// Одна запись на событие + fsync: после сбоя в файле может остаться
+	// только недописанный хвост, который отбрасывается при чтении
+	if _, err := s.active.Write(data); err != nil {
+		s.discardPartial()
+		return fmt.Errorf("failed to write spool segment: %w", err)
+	}
+	if err := s.active.Sync(); err != nil {
+		s.discardPartial()
+		return fmt.Errorf("failed to sync spool segment: %w", err)
+	}
+	s.activeSize += int64(len(data))
+	return nil
+}
+
+// discardPartial убирает недописанную при ошибке (ENOSPC, EIO) запись,
+// иначе следующее событие склеится с ней в одну некорректную строку и
+// тоже потеряется при досылке. Если обрезать файл не удалось, сегмент
+// закрывается: недописанный хвост в конце сегмента отбрасывается при
+// чтении. Вызывается под mu.
+func (s *spool) discardPartial() {
+	if err := s.active.Truncate(s.activeSize); err != nil {
+		s.seal()
+	}
+}
+
+// seal закрывает активный сегмент и применяет общий лимит. Вызывается под mu.
+func (s *spool) seal() {
+	if s.active == nil {
+		return
+	}
+	s.active.Close()
+	s.active = nil
+	s.enforceLimit()
+}
+
+// enforceLimit удаляет самые старые сегменты сверх MaxTotalBytes. Вызывается под mu.
+func (s *spool) enforceLimit() {
+	segments, err := s.listSegments()
+	if err != nil {
+		return
+	}
+	var total int64
+	sizes := make([]int64, len(segments))
+	for i, seq := range segments {
+		if info, err := os.Stat(s.segmentPath(seq)); err == nil {
+			sizes[i] = info.Size()
+			total += sizes[i]
+		}
+	}
+	for i := 0; i < len(segments) && total > s.cfg.MaxTotalBytes; i++ {
+		if segments[i] == s.activeSeq && s.active != nil {
+			continue
+		}
+		s.removeSegment(segments[i])
+		total -= sizes[i]
+	}
+}
+
+// sealed возвращает закрытые сегменты по порядку, закрывая активный
+func (s *spool) sealed() ([]uint64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.init(); err != nil {
+		return nil, err
+	}
+	s.seal()
+	return s.listSegments()
+}
+
+// replay досылает сегменты по порядку, останавливаясь на первой ошибке
+func (s *spool) replay(ctx context.Context) error {
+	s.replayMu.Lock()
+	defer s.replayMu.Unlock()
+
+	segments, err := s.sealed()
+	if err != nil {
+		return err
+	}
+	for _, seq := range segments {
+		if err := s.replaySegment(ctx, seq); err != nil {
+			return err
+		}
+		s.mu.Lock()
+		s.removeSegment(seq)
+		s.mu.Unlock()
+	}
+	return nil
+}
+
+// replaySegment досылает события сегмента начиная с сохраненного смещения
+func (s *spool) replaySegment(ctx context.Context, seq uint64) error {
+	f, err := os.Open(s.segmentPath(seq))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return fmt.Errorf("failed to open spool segment: %w", err)
+	}
+	defer f.Close()
+
+	offset := s.readOffset(seq)
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		return fmt.Errorf("failed to seek spool segment: %w", err)
+	}
+
+	reader := bufio.NewReader(f)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err != nil {
+			// Строка без перевода строки - недописанная запись после сбоя
+			return nil
+		}
+		offset += int64(len(line))
+
+		data := line[:len(line)-1]
+		if json.Valid(data) {
+			if err := s.send(ctx, data); err != nil {
+				return err
+			}
+		}
+		if err := s.writeOffset(seq, offset); err != nil {
+			return err
+		}
+	}
+}
+
+// run периодически досылает события до закрытия spool
+func (s *spool) run() {
+	defer close(s.finished)
+	ticker := time.NewTicker(s.cfg.ReplayInterval)
+	defer ticker.Stop()
+
+	// События прошлого запуска досылаются сразу
+	s.replay(context.Background())
+	for {
+		select {
+		case <-ticker.C:
+			s.replay(context.Background())
+		case <-s.done:
+			return
+		}
+	}
+}
+
+// close останавливает досылку и закрывает активный сегмент
+func (s *spool) close(ctx context.Context) error {
+	s.closeOnce.Do(func() {
+		close(s.done)
+	})
+
+	if s.started {
+		select {
+		case <-s.finished:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.active != nil {
+		s.active.Close()
+		s.active = nil
+	}
+	return nil
+}
+
+// listSegments возвращает номера сегментов в каталоге по возрастанию
+func (s *spool) listSegments() ([]uint64, error) {
+	entries, err := os.ReadDir(s.cfg.Dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read spool directory: %w", err)
+	}
+	var segments []uint64
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, spoolSegmentExt) {
+			continue
+		}
+		seq, err := strconv.ParseUint(strings.TrimSuffix(name, spoolSegmentExt), 10, 64)
+		if err != nil {
+			continue
+		}
+		segments = append(segments, seq)
+	}
+	sort.Slice(segments, func(i, j int) bool { return segments[i] < segments[j] })
+	return segments, nil
+}
+
+// removeSegment удаляет сегмент вместе с его смещением
+func (s *spool) removeSegment(seq uint64) {
+	os.Remove(s.segmentPath(seq))
+	os.Remove(s.offsetPath(seq))
+}
+
+// readOffset возвращает смещение первого недосланного события
+func (s *spool) readOffset(seq uint64) int64 {
+	data, err := os.ReadFile(s.offsetPath(seq))
+	if err != nil {
+		return 0
+	}
+	offset, err := strconv.ParseInt(strings.TrimSpace(string(data)), 10, 64)
+	if err != nil || offset < 0 {
+		return 0
+	}
+	return offset
+}
+
+// writeOffset атомарно сохраняет смещение через временный файл и rename
+func (s *spool) writeOffset(seq uint64, offset int64) error {
+	tmp := s.offsetPath(seq) + ".tmp"
+	if err := os.WriteFile(tmp, []byte(strconv.FormatInt(offset, 10)), 0o644); err != nil {
+		return fmt.Errorf("failed to write spool offset: %w", err)
+	}
+	if err := os.Rename(tmp, s.offsetPath(seq)); err != nil {
+		return fmt.Errorf("failed to write spool offset: %w", err)
+	}
+	return nil
+}
+
+func (s *spool) segmentPath(seq uint64) string {
+	return filepath.Join(s.cfg.Dir, fmt.Sprintf("%020d%s", seq, spoolSegmentExt))
+}
+
+func (s *spool) offsetPath(seq uint64) string {
+	return filepath.Join(s.cfg.Dir, fmt.Sprintf("%020d%s", seq, spoolOffsetExt))
+}
+
+// spoolable сообщает, стоит ли сохранять событие после такой ошибки.
+// Ответы 4xx повторятся и при досылке, поэтому такие события не сохраняются.
+func (c *Client) spoolable(req LogRequest, err error) bool {
+	if c.spool == nil || !c.spool.accepts(req) {
+		return false
+	}
+	return errors.Is(err, ErrCircuitOpen) || isRetryable(err)
+}
+
+// spoolWrite сохраняет событие на диск и обновляет счетчики
+func (c *Client) spoolWrite(req LogRequest) bool {
+	if err := c.spool.write(req); err != nil {
+		return false
+	}
+	c.stats.spooled.Add(1)
+	return true
+}
+
+// replaySend досылает одно событие из spool
+func (c *Client) replaySend(ctx context.Context, data []byte) error {
+	// event_id берется из сохраненного события, чтобы повтор после
+	// неудачной досылки не создал дубликат
+	var stored struct {
+		EventID string `json:"event_id"`
+	}
+	json.Unmarshal(data, &stored)
+	err := c.postBody(ctx, c.endpointPath, "application/json", stored.EventID, data)
+	if err == nil {
+		c.stats.sent.Add(1)
+		return nil
+	}
+	if !errors.Is(err, ErrCircuitOpen) && !isRetryable(err) {
+		// Сервис отверг событие - повторять бессмысленно, пропускаем его
+		c.stats.failed.Add(1)
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,281 @@
+package logging
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// spoolCollector собирает досланные события
+type spoolCollector struct {
+	mu       sync.Mutex
+	messages []string
+	failAt   int // номер вызова, на котором send вернет ошибку (0 - никогда)
+	calls    int
+}
+
+func (sc *spoolCollector) send(ctx context.Context, data []byte) error {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	sc.calls++
+	if sc.calls == sc.failAt {
+		return errors.New("logging-service unavailable")
+	}
+	var req LogRequest
+	json.Unmarshal(data, &req)
+	sc.messages = append(sc.messages, req.Message)
+	return nil
+}
+
+func (sc *spoolCollector) received() []string {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	return append([]string(nil), sc.messages...)
+}
+
+func expectMessages(t *testing.T, expected, got []string) {
+	t.Helper()
+	if len(got) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, got)
+	}
+	for i := range expected {
+		if got[i] != expected[i] {
+			t.Fatalf("expected %v, got %v", expected, got)
+		}
+	}
+}
+
+func TestSpool_ReplayInOrderAcrossSegments(t *testing.T) {
+	dir := t.TempDir()
+	collector := &spoolCollector{}
+	s := newSpool(SpoolConfig{Dir: dir, MaxSegmentBytes: 150, ReplayInterval: time.Hour}, collector.send)
+	defer s.close(context.Background())
+
+	var expected []string
+	for i := 0; i < 6; i++ {
+		msg := "event-" + strconv.Itoa(i)
+		expected = append(expected, msg)
+		if err := s.write(LogRequest{Level: "ERROR", Event: "error_event", Message: msg}); err != nil {
+			t.Fatalf("unexpected write error: %v", err)
+		}
+	}
+
+	segments, _ := s.listSegments()
+	if len(segments) < 2 {
+		t.Fatalf("expected rotation into several segments, got %d", len(segments))
+	}
+
+	if err := s.replay(context.Background()); err != nil {
+		t.Fatalf("unexpected replay error: %v", err)
+	}
+	expectMessages(t, expected, collector.received())
+
+	if segments, _ := s.listSegments(); len(segments) != 0 {
+		t.Errorf("expected replayed segments to be removed, got %v", segments)
+	}
+}
+
+func TestSpool_ResumesFromOffsetAfterFailure(t *testing.T) {
+	dir := t.TempDir()
+	collector := &spoolCollector{failAt: 3}
+	s := newSpool(SpoolConfig{Dir: dir, ReplayInterval: time.Hour}, collector.send)
+	defer s.close(context.Background())
+
+	for i := 0; i < 4; i++ {
+		s.write(LogRequest{Level: "ERROR", Message: strconv.Itoa(i)})
+	}
+
+	if err := s.replay(context.Background()); err == nil {
+		t.Fatal("expected replay to stop on send error")
+	}
+	if err := s.replay(context.Background()); err != nil {
+		t.Fatalf("unexpected replay error: %v", err)
+	}
+	expectMessages(t, []string{"0", "1", "2", "3"}, collector.received())
+}
+
+func TestSpool_IgnoresPartialWrite(t *testing.T) {
+	dir := t.TempDir()
+	valid, _ := json.Marshal(LogRequest{Level: "ERROR", Message: "complete"})
+	content := string(valid) + "\n" + "not json\n" + `{"level":"ERROR","message":"trunc`
+	if err := os.WriteFile(filepath.Join(dir, "00000000000000000007.ndjson"), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	collector := &spoolCollector{}
+	s := newSpool(SpoolConfig{Dir: dir, ReplayInterval: time.Hour}, collector.send)
+	defer s.close(context.Background())
+
+	if err := s.replay(context.Background()); err != nil {
+		t.Fatalf("unexpected replay error: %v", err)
+	}
+	expectMessages(t, []string{"complete"}, collector.received())
+
+	// Новые события не дописываются в сегмент прошлого процесса
+	s.write(LogRequest{Level: "ERROR", Message: "new"})
+	if _, err := os.Stat(filepath.Join(dir, "00000000000000000008.ndjson")); err != nil {
+		t.Errorf("expected new segment after existing ones: %v", err)
+	}
+}
+
+// failingSegment дописывает половину первой записи и возвращает ошибку,
+// как при нехватке места на диске
+type failingSegment struct {
+	segmentFile
+	failed        bool
+	truncateError error
+}
+
+func (f *failingSegment) Write(p []byte) (int, error) {
+	if f.failed {
+		return f.segmentFile.Write(p)
+	}
+	f.failed = true
+	n, _ := f.segmentFile.Write(p[:len(p)/2])
+	return n, errors.New("no space left on device")
+}
+
+func (f *failingSegment) Truncate(size int64) error {
+	if f.truncateError != nil {
+		return f.truncateError
+	}
+	return f.segmentFile.Truncate(size)
+}
+
+func TestSpool_FailedWriteDoesNotCorruptNextEvent(t *testing.T) {
+	for _, truncateError := range []error{nil, errors.New("read-only file system")} {
+		dir := t.TempDir()
+		collector := &spoolCollector{}
+		s := newSpool(SpoolConfig{Dir: dir, ReplayInterval: time.Hour}, collector.send)
+
+		s.write(LogRequest{Level: "ERROR", Message: "before"})
+		s.active = &failingSegment{segmentFile: s.active, truncateError: truncateError}
+		if err := s.write(LogRequest{Level: "ERROR", Message: "failed"}); err == nil {
+			t.Fatal("expected write error")
+		}
+		s.write(LogRequest{Level: "ERROR", Message: "after"})
+
+		if err := s.replay(context.Background()); err != nil {
+			t.Fatalf("unexpected replay error: %v", err)
+		}
+		expectMessages(t, []string{"before", "after"}, collector.received())
+		s.close(context.Background())
+	}
+}
+
+func TestSpool_MaxTotalBytesDropsOldest(t *testing.T) {
+	dir := t.TempDir()
+	collector := &spoolCollector{}
+	s := newSpool(SpoolConfig{Dir: dir, MaxSegmentBytes: 60, MaxTotalBytes: 120, ReplayInterval: time.Hour}, collector.send)
+	defer s.close(context.Background())
+
+	for i := 0; i < 10; i++ {
+		s.write(LogRequest{Level: "ERROR", Message: strconv.Itoa(i)})
+	}
+	s.replay(context.Background())
+
+	got := collector.received()
+	if len(got) == 0 || len(got) >= 10 {
+		t.Fatalf("expected oldest events to be dropped, got %v", got)
+	}
+	if got[len(got)-1] != "9" {
+		t.Errorf("expected newest event to survive, got %v", got)
+	}
+}
+
+func TestSpool_ClientSpoolsFailedEventsAndReplaysAfterRestart(t *testing.T) {
+	dir := t.TempDir()
+	var available atomic.Bool
+	var mu sync.Mutex
+	var delivered []LogRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !available.Load() {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		var req LogRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		mu.Lock()
+		delivered = append(delivered, req)
+		mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	cfg := config{Spool: &SpoolConfig{Dir: dir, ReplayInterval: time.Hour}}
+	client := newClient(server.URL, "test-service", cfg)
+	client.Error(errors.New("boom"), "first failure", nil)
+	client.Info("test_event", "info is not spooled", nil)
+	client.Critical("second failure", nil)
+
+	if stats := client.Stats(); stats.Spooled != 2 {
+		t.Errorf("expected 2 spooled events, got %d", stats.Spooled)
+	}
+	client.Close(context.Background())
+
+	// Новый процесс досылает события сразу после старта
+	available.Store(true)
+	restarted := newClient(server.URL, "test-service", cfg)
+	defer restarted.Close(context.Background())
+
+	waitFor(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(delivered) == 2
+	})
+	mu.Lock()
+	defer mu.Unlock()
+	if delivered[0].Message != "first failure" || delivered[1].Message != "second failure" {
+		t.Errorf("unexpected replay order: %s, %s", delivered[0].Message, delivered[1].Message)
+	}
+	if delivered[0].Level != "ERROR" || delivered[1].Level != "CRITICAL" {
+		t.Errorf("unexpected levels: %s, %s", delivered[0].Level, delivered[1].Level)
+	}
+}
+
+func TestSpool_ClientErrorsAreNotSpooled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	client := newClient(server.URL, "test-service", config{
+		Spool: &SpoolConfig{Dir: t.TempDir(), ReplayInterval: time.Hour},
+	})
+	defer client.Close(context.Background())
+
+	client.Critical("rejected", nil)
+	if stats := client.Stats(); stats.Spooled != 0 {
+		t.Errorf("expected no spooled events for 4xx, got %d", stats.Spooled)
+	}
+}
+
+func TestSpool_BreakerRoutesToSpool(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := newClient(server.URL, "test-service", config{
+		Breaker: &BreakerConfig{FailureThreshold: 1, OpenTimeout: time.Hour},
+		Spool:   &SpoolConfig{Dir: t.TempDir(), ReplayInterval: time.Hour},
+	})
+	defer client.Close(context.Background())
+
+	client.Critical("trips breaker", nil)
+	client.Critical("short-circuited", nil)
+
+	stats := client.Stats()
+	if stats.Spooled != 2 || stats.ShortCircuited != 0 {
+		t.Errorf("expected both events spooled, got %+v", stats)
+	}
+}
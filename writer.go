@@ -0,0 +1,140 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// maxWriterLineBytes максимальная длина строки; более длинные строки
+// отправляются частями
+const maxWriterLineBytes = 64 << 10
+
+// LogWriter io.Writer, который отправляет каждую строку отдельным событием.
+// Создается через Client.Writer.
+type LogWriter struct {
+	client *Client
+	level  Level
+	event  string
+
+	mu  sync.Mutex
+	buf []byte
+	// continued буфер начинается с продолжения разрезанной строки;
+	// lineLevel уровень, определенный по началу этой строки
+	continued bool
+	lineLevel Level
+}
+
+// Writer возвращает io.Writer для стандартного пакета log и сторонних
+// библиотек. Каждая строка становится событием event уровня level.
+// Префикс вида "[ERROR]" в начале строки переопределяет уровень:
+//
+//	log.SetFlags(0)
+//	log.SetOutput(client.Writer(logging.LevelInfo, "stdlog"))
+//	log.Printf("[WARN] cache is cold")
+//
+// Незавершенная строка ждет следующей записи или вызова Close.
+func (c *Client) Writer(level Level, event string) *LogWriter {
+	return &LogWriter{client: c, level: level, event: event}
+}
+
+// Write разбивает данные на строки и отправляет завершенные строки.
+// Возвращает первую ошибку отправки; данные при этом считаются записанными.
+func (w *LogWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	var firstErr error
+	keep := func(err error) {
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	w.buf = append(w.buf, p...)
+	for {
+		i := bytes.IndexByte(w.buf, '\n')
+		if i >= 0 && i <= maxWriterLineBytes {
+			keep(w.send(w.buf[:i], false))
+			w.buf = w.buf[i+1:]
+			continue
+		}
+		if len(w.buf) <= maxWriterLineBytes {
+			break
+		}
+		n := splitPoint(w.buf, maxWriterLineBytes)
+		keep(w.send(w.buf[:n], true))
+		w.buf = w.buf[n:]
+	}
+	// Не держим большой массив ради короткого хвоста
+	w.buf = append([]byte(nil), w.buf...)
+
+	return len(p), firstErr
+}
+
+// Close отправляет незавершенную строку. Клиент при этом не закрывается.
+func (w *LogWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if len(w.buf) == 0 {
+		return nil
+	}
+	err := w.send(w.buf, false)
+	w.buf = nil
+	return err
+}
+
+// send отправляет часть строки; more означает, что строка разрезана и
+// продолжится следующей частью. Все части длинной строки отмечаются
+// partial, а префикс уровня разбирается только в ее начале.
+func (w *LogWriter) send(line []byte, more bool) error {
+	text := strings.TrimRight(string(line), "\r\n")
+	level := w.lineLevel
+	partial := more || w.continued
+	if !w.continued {
+		level, text = parseLevelPrefix(text, w.level)
+	}
+	w.continued = more
+	w.lineLevel = level
+
+	if strings.TrimSpace(text) == "" {
+		return nil
+	}
+	var metadata map[string]interface{}
+	if partial {
+		metadata = map[string]interface{}{"partial": true}
+	}
+	return w.client.sendLogContext(context.Background(), level, w.event, text, metadata)
+}
+
+// parseLevelPrefix отрезает префикс уровня вида "[ERROR]" в начале строки
+func parseLevelPrefix(text string, fallback Level) (Level, string) {
+	if !strings.HasPrefix(text, "[") {
+		return fallback, text
+	}
+	end := strings.IndexByte(text, ']')
+	if end < 0 || end > len("[CRITICAL]") {
+		return fallback, text
+	}
+	level, err := ParseLevel(text[1:end])
+	if err != nil {
+		return fallback, text
+	}
+	return level, strings.TrimLeft(text[end+1:], " ")
+}
+
+// splitPoint возвращает позицию разреза не дальше max, не разрывая
+// символ UTF-8
+func splitPoint(b []byte, max int) int {
+	n := max
+	for n > 0 && !utf8.RuneStart(b[n]) {
+		n--
+	}
+	if n == 0 {
+		return max
+	}
+	return n
+}
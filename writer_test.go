@@ -0,0 +1,137 @@
+package logging
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestWriter_SplitsLinesAndParsesLevel(t *testing.T) {
+	server, received := captureServer(t)
+	client := NewClient(server.URL, "test-service")
+
+	logger := log.New(client.Writer(LevelInfo, "stdlog"), "", 0)
+	logger.Printf("cache warmed")
+	logger.Printf("[ERROR] search failed\nsecond line")
+	logger.Printf("[warn]  slow response")
+
+	events := received()
+	if len(events) != 4 {
+		t.Fatalf("expected 4 events, got %d", len(events))
+	}
+	expected := []struct{ level, message string }{
+		{"INFO", "cache warmed"},
+		{"ERROR", "search failed"},
+		{"INFO", "second line"},
+		{"WARNING", "slow response"},
+	}
+	for i, want := range expected {
+		if events[i].Level != want.level || events[i].Message != want.message {
+			t.Errorf("event %d: expected %s %q, got %s %q", i, want.level, want.message, events[i].Level, events[i].Message)
+		}
+		if events[i].Event != "stdlog" {
+			t.Errorf("event %d: expected event stdlog, got %s", i, events[i].Event)
+		}
+	}
+}
+
+func TestWriter_BuffersPartialWrites(t *testing.T) {
+	server, received := captureServer(t)
+	client := NewClient(server.URL, "test-service")
+	w := client.Writer(LevelInfo, "stdlog")
+
+	fmt.Fprint(w, "hello ")
+	fmt.Fprint(w, "world\r\n\n")
+	fmt.Fprint(w, "tail")
+	if len(received()) != 1 {
+		t.Fatalf("expected 1 event before Close, got %d", len(received()))
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	events := received()
+	if len(events) != 2 {
+		t.Fatalf("expected 2 events, got %d", len(events))
+	}
+	if events[0].Message != "hello world" || events[1].Message != "tail" {
+		t.Errorf("unexpected messages: %q, %q", events[0].Message, events[1].Message)
+	}
+}
+
+func TestWriter_SplitsLongLines(t *testing.T) {
+	server, received := captureServer(t)
+	client := NewClient(server.URL, "test-service")
+	w := client.Writer(LevelInfo, "stdlog")
+
+	line := strings.Repeat("я", maxWriterLineBytes) // 2 байта на символ
+	if _, err := fmt.Fprintln(w, line); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	events := received()
+	if len(events) != 2 {
+		t.Fatalf("expected 2 events, got %d", len(events))
+	}
+	var total int
+	for i, req := range events {
+		if !utf8.ValidString(req.Message) {
+			t.Errorf("event %d: message split inside a rune", i)
+		}
+		if len(req.Message) > maxWriterLineBytes {
+			t.Errorf("event %d: expected at most %d bytes, got %d", i, maxWriterLineBytes, len(req.Message))
+		}
+		total += len(req.Message)
+	}
+	if total != len(line) {
+		t.Errorf("expected %d bytes in total, got %d", len(line), total)
+	}
+	if events[0].Metadata["partial"] != true {
+		t.Errorf("expected first chunk to be marked partial, got %v", events[0].Metadata)
+	}
+}
+
+func TestWriter_LongLineTailIsContinuation(t *testing.T) {
+	server, received := captureServer(t)
+	client := NewClient(server.URL, "test-service")
+	w := client.Writer(LevelInfo, "stdlog")
+
+	fmt.Fprint(w, "[WARN] "+strings.Repeat("a", maxWriterLineBytes)+"[ERROR] tail\n")
+	fmt.Fprint(w, "[ERROR] next line\n")
+
+	events := received()
+	if len(events) != 3 {
+		t.Fatalf("expected 3 events, got %d", len(events))
+	}
+	for i, req := range events[:2] {
+		if req.Level != "WARNING" || req.Metadata["partial"] != true {
+			t.Errorf("chunk %d: expected partial WARNING, got %s %v", i, req.Level, req.Metadata)
+		}
+	}
+	if !strings.HasSuffix(events[1].Message, "[ERROR] tail") {
+		t.Errorf("expected prefix inside the line to stay in the message, got %q", events[1].Message)
+	}
+	if events[2].Level != "ERROR" || events[2].Message != "next line" || events[2].Metadata != nil {
+		t.Errorf("expected next line to start fresh, got %s %q %v", events[2].Level, events[2].Message, events[2].Metadata)
+	}
+}
+
+func TestWriter_RespectsMinLevel(t *testing.T) {
+	server, received := captureServer(t)
+	client := NewClient(server.URL, "test-service", WithMinLevel(LevelWarning))
+	w := client.Writer(LevelInfo, "stdlog")
+
+	fmt.Fprintln(w, "routine message")
+	fmt.Fprintln(w, "[CRITICAL] disk full")
+	fmt.Fprintln(w, "[NOTICE] unknown prefix")
+
+	events := received()
+	if len(events) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(events))
+	}
+	if events[0].Level != "CRITICAL" || events[0].Message != "disk full" {
+		t.Errorf("unexpected event: %s %q", events[0].Level, events[0].Message)
+	}
+}